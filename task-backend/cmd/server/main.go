@@ -1,19 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/Mahathirrr/task-management-backend/internal/config"
 	"github.com/Mahathirrr/task-management-backend/internal/database"
 	"github.com/Mahathirrr/task-management-backend/internal/handler"
 	"github.com/Mahathirrr/task-management-backend/internal/repository"
 	"github.com/Mahathirrr/task-management-backend/internal/router"
+	"github.com/Mahathirrr/task-management-backend/internal/scheduler"
 	"github.com/Mahathirrr/task-management-backend/internal/service"
 	"github.com/Mahathirrr/task-management-backend/pkg/jwt"
+	"github.com/Mahathirrr/task-management-backend/pkg/notifier"
 	"github.com/Mahathirrr/task-management-backend/pkg/oauth"
+	"github.com/Mahathirrr/task-management-backend/pkg/storage"
 )
 
 func main() {
@@ -46,20 +51,85 @@ func main() {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(database.GetDB())
 	taskRepo := repository.NewTaskRepository(database.GetDB())
+	labelRepo := repository.NewLabelRepository(database.GetDB())
+	projectRepo := repository.NewProjectRepository(database.GetDB())
+	commentRepo := repository.NewCommentRepository(database.GetDB())
+	attachmentRepo := repository.NewAttachmentRepository(database.GetDB())
+	activityRepo := repository.NewActivityRepository(database.GetDB())
+	workflowRepo := repository.NewWorkflowRepository(database.GetDB())
+	seriesRepo := repository.NewSeriesRepository(database.GetDB())
+	worklogRepo := repository.NewWorklogRepository(database.GetDB())
+	templateRepo := repository.NewTemplateRepository(database.GetDB())
+	calendarTokenRepo := repository.NewCalendarTokenRepository(database.GetDB())
+	transactor := repository.NewTransactor(database.GetDB())
+
+	// Initialize file storage
+	fileStorage, err := storage.NewLocalStorage(cfg.Upload.AttachmentsDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, jwtManager)
 	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo)
+	taskService := service.NewTaskService(taskRepo, labelRepo, projectRepo, userRepo, attachmentRepo, fileStorage, activityRepo, workflowRepo, seriesRepo, transactor)
+	labelService := service.NewLabelService(labelRepo)
+	projectService := service.NewProjectService(projectRepo, workflowRepo)
+	commentService := service.NewCommentService(commentRepo, taskService)
+	workflowService := service.NewWorkflowService(workflowRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskService, fileStorage, cfg.Upload.MaxSize, cfg.Upload.AllowedTypes)
+	worklogService := service.NewWorklogService(worklogRepo, taskService)
+	templateService := service.NewTemplateService(templateRepo, labelRepo, userRepo, taskService)
+	calendarService := service.NewCalendarService(calendarTokenRepo, userRepo, taskService)
+
+	// Status yang dikenal validator diambil dari workflow di database
+	if err := workflowService.RefreshKnownStatuses(); err != nil {
+		log.Fatalf("Failed to load workflows: %v", err)
+	}
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
 	oauthHandler := handler.NewOAuthHandler(authService, oauthManager)
 	taskHandler := handler.NewTaskHandler(taskService)
+	labelHandler := handler.NewLabelHandler(labelService)
+	projectHandler := handler.NewProjectHandler(projectService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, cfg.Upload.MaxSize)
+	workflowHandler := handler.NewWorkflowHandler(workflowService)
+	worklogHandler := handler.NewWorklogHandler(worklogService)
+	templateHandler := handler.NewTemplateHandler(templateService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
 	adminHandler := handler.NewAdminHandler(userService)
 
+	// Start background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.Reminder.Enabled {
+		var reminderNotifier notifier.Notifier = notifier.NewLogNotifier()
+		if cfg.SMTP.Host != "" {
+			reminderNotifier = notifier.NewSMTPNotifier(
+				cfg.SMTP.Host,
+				cfg.SMTP.Port,
+				cfg.SMTP.Username,
+				cfg.SMTP.Password,
+				cfg.SMTP.FromEmail,
+				cfg.SMTP.FromName,
+			)
+		}
+
+		reminderScheduler := scheduler.NewReminderScheduler(taskRepo, userRepo, reminderNotifier, cfg.Reminder.LeadTime, cfg.Reminder.Interval)
+		reminderScheduler.Start(ctx)
+	}
+
+	if cfg.Trash.RetentionDays > 0 {
+		retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+		purgeScheduler := scheduler.NewPurgeScheduler(taskService, retention, cfg.Trash.PurgeInterval)
+		purgeScheduler.Start(ctx)
+	}
+
 	// Setup routes
-	routerHandler := router.SetupRoutes(authHandler, oauthHandler, taskHandler, adminHandler, jwtManager, &cfg.CORS)
+	routerHandler := router.SetupRoutes(authHandler, oauthHandler, taskHandler, labelHandler, projectHandler, commentHandler, attachmentHandler, workflowHandler, worklogHandler, templateHandler, calendarHandler, adminHandler, jwtManager, &cfg.CORS)
 
 	// --- Server Config (lokal vs Railway) ---
 	port := os.Getenv("PORT") // Railway inject PORT
@@ -3,6 +3,7 @@ module github.com/Mahathirrr/task-management-backend
 go 1.24.4
 
 require (
+	github.com/evanphx/json-patch/v5 v5.9.11
 	github.com/go-playground/validator/v10 v10.27.0
 	github.com/go-sql-driver/mysql v1.9.3
 	github.com/golang-jwt/jwt/v5 v5.3.0
@@ -10,7 +11,10 @@ require (
 	github.com/gorilla/mux v1.8.1
 	github.com/markbates/goth v1.81.0
 	github.com/spf13/viper v1.20.1
+	github.com/teambition/rrule-go v1.8.2
+	github.com/yuin/goldmark v1.8.6
 	golang.org/x/crypto v0.33.0
+	golang.org/x/net v0.34.0
 )
 
 require (
@@ -32,7 +36,6 @@ require (
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
-	golang.org/x/net v0.34.0 // indirect
 	golang.org/x/oauth2 v0.25.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/viper"
@@ -12,6 +13,10 @@ type Config struct {
 	JWT      JWTConfig
 	OAuth    OAuthConfig
 	CORS     CORSConfig
+	Reminder ReminderConfig
+	SMTP     SMTPConfig
+	Upload   UploadConfig
+	Trash    TrashConfig
 }
 
 type ServerConfig struct {
@@ -48,10 +53,37 @@ type CORSConfig struct {
 	AllowedOrigins   []string `mapstructure:"allowed_origins"`
 	AllowedMethods   []string `mapstructure:"allowed_methods"`
 	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`
 	AllowCredentials bool     `mapstructure:"allow_credentials"`
 	MaxAge           int      `mapstructure:"max_age"`
 }
 
+type ReminderConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	LeadTime time.Duration `mapstructure:"lead_time"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type SMTPConfig struct {
+	Host      string `mapstructure:"host"`
+	Port      int    `mapstructure:"port"`
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+	FromEmail string `mapstructure:"from_email"`
+	FromName  string `mapstructure:"from_name"`
+}
+
+type UploadConfig struct {
+	MaxSize        int64    `mapstructure:"max_size"`
+	AllowedTypes   []string `mapstructure:"allowed_types"`
+	AttachmentsDir string   `mapstructure:"attachments_dir"`
+}
+
+type TrashConfig struct {
+	RetentionDays int           `mapstructure:"retention_days"` // 0 menonaktifkan purge otomatis
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
 func LoadConfig() (*Config, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -67,11 +99,26 @@ func LoadConfig() (*Config, error) {
 
 	// CORS defaults
 	viper.SetDefault("cors.allowed_origins", []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:8080"})
-	viper.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
-	viper.SetDefault("cors.allowed_headers", []string{"Content-Type", "Authorization", "X-Requested-With"})
+	viper.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	viper.SetDefault("cors.allowed_headers", []string{"Content-Type", "Authorization", "X-Requested-With", "If-Match"})
+	viper.SetDefault("cors.exposed_headers", []string{"ETag"})
 	viper.SetDefault("cors.allow_credentials", true)
 	viper.SetDefault("cors.max_age", 86400)
 
+	// Reminder defaults
+	viper.SetDefault("reminder.enabled", true)
+	viper.SetDefault("reminder.lead_time", "30m")
+	viper.SetDefault("reminder.interval", "1m")
+
+	// Upload defaults
+	viper.SetDefault("upload.max_size", 5242880)
+	viper.SetDefault("upload.allowed_types", []string{"image/jpeg", "image/png", "image/gif", "image/webp", "text/plain", "application/pdf"})
+	viper.SetDefault("upload.attachments_dir", "./shared/uploads/attachments")
+
+	// Trash defaults
+	viper.SetDefault("trash.retention_days", 30)
+	viper.SetDefault("trash.purge_interval", "1h")
+
 	// Allow environment variables
 	viper.AutomaticEnv()
 	if err := viper.ReadInConfig(); err != nil {
@@ -83,5 +130,31 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
+
+// Validate menolak konfigurasi scheduler yang tidak bisa dijalankan, misalnya
+// interval yang tidak positif (time.NewTicker panic untuk nilai tersebut)
+func (c *Config) Validate() error {
+	if c.Reminder.Enabled {
+		if c.Reminder.Interval <= 0 {
+			return fmt.Errorf("reminder.interval must be positive, got %s", c.Reminder.Interval)
+		}
+		if c.Reminder.LeadTime <= 0 {
+			return fmt.Errorf("reminder.lead_time must be positive, got %s", c.Reminder.LeadTime)
+		}
+	}
+
+	if c.Trash.RetentionDays < 0 {
+		return fmt.Errorf("trash.retention_days must not be negative, got %d", c.Trash.RetentionDays)
+	}
+	if c.Trash.RetentionDays > 0 && c.Trash.PurgeInterval <= 0 {
+		return fmt.Errorf("trash.purge_interval must be positive, got %s", c.Trash.PurgeInterval)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead adalah ruang tambahan untuk boundary dan header multipart
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	attachmentService service.AttachmentService
+	maxSize           int64
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxSize:           maxSize,
+	}
+}
+
+// GetAttachments menangani pengambilan daftar attachment pada task
+func (h *AttachmentHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	attachments, err := h.attachmentService.GetTaskAttachments(taskID, claims.UserID, isAdmin)
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, attachments)
+}
+
+// UploadAttachment menangani upload file multipart (field "file") ke task.
+// File di-stream langsung ke storage tanpa ditampung di memory.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Request must be multipart/form-data")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeAttachmentError(w, err)
+			return
+		}
+
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.attachmentService.UploadAttachment(taskID, claims.UserID, part.FileName(), part, isAdmin)
+		part.Close()
+		if err != nil {
+			writeAttachmentError(w, err)
+			return
+		}
+
+		response.Created(w, attachment)
+		return
+	}
+
+	response.Error(w, http.StatusBadRequest, "File is required")
+}
+
+// DownloadAttachment menangani download attachment dengan streaming
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, attachmentID, ok := parseAttachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	attachment, content, err := h.attachmentService.OpenAttachment(taskID, attachmentID, claims.UserID, isAdmin)
+	if err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("Failed to stream attachment %d: %v", attachment.ID, err)
+	}
+}
+
+// DeleteAttachment menangani penghapusan attachment
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, attachmentID, ok := parseAttachmentPath(w, r)
+	if !ok {
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	if err := h.attachmentService.DeleteAttachment(taskID, attachmentID, claims.UserID, isAdmin); err != nil {
+		writeAttachmentError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgAttachmentDeleted)
+}
+
+// parseAttachmentPath membaca task ID dan attachment ID dari path, menulis 400 jika tidak valid
+func parseAttachmentPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return 0, 0, false
+	}
+
+	attachmentID, err := strconv.Atoi(vars["attachmentId"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid attachment ID")
+		return 0, 0, false
+	}
+
+	return taskID, attachmentID, true
+}
+
+// writeAttachmentError memetakan error dari AttachmentService ke HTTP response
+func writeAttachmentError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.Error(w, http.StatusRequestEntityTooLarge, model.ErrAttachmentTooLarge)
+		return
+	}
+
+	switch err.Error() {
+	case model.ErrTaskNotFound, model.ErrAttachmentNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrAttachmentTooLarge:
+		response.Error(w, http.StatusRequestEntityTooLarge, err.Error())
+	case model.ErrAttachmentTypeNotAllowed:
+		response.Error(w, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
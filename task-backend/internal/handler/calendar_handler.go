@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/ical"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/gorilla/mux"
+)
+
+// icalPriorities memetakan priority task ke PRIORITY iCalendar (1 tertinggi)
+var icalPriorities = map[model.TaskPriority]string{
+	model.TaskPriorityUrgent: "1",
+	model.TaskPriorityHigh:   "3",
+	model.TaskPriorityMedium: "5",
+	model.TaskPriorityLow:    "9",
+}
+
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetToken menangani pengecekan token feed kalender milik user
+func (h *CalendarHandler) GetToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	token, err := h.calendarService.GetToken(claims.UserID)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, token)
+}
+
+// RegenerateToken menangani pembuatan token feed baru, token lama dicabut
+func (h *CalendarHandler) RegenerateToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	token, saved, err := h.calendarService.RegenerateToken(claims.UserID)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	response.Created(w, model.CalendarTokenResponse{
+		Token:     token,
+		FeedURL:   fmt.Sprintf("%s/api/v1/calendar/%s.ics", requestBaseURL(r), token),
+		CreatedAt: saved.CreatedAt,
+	})
+}
+
+// RevokeToken menangani pencabutan token feed
+func (h *CalendarHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	if err := h.calendarService.RevokeToken(claims.UserID); err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgCalendarRevoked)
+}
+
+// GetFeed menangani feed iCalendar. Aplikasi kalender tidak bisa mengirim
+// bearer token, sehingga user dikenali dari token rahasia pada URL. Task
+// ditulis sebagai VEVENT, atau VTODO dengan ?type=todo.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get("type")
+	if kind == "" {
+		kind = model.CalendarFeedEvent
+	}
+	if kind != model.CalendarFeedEvent && kind != model.CalendarFeedTodo {
+		response.ValidationError(w, []model.ValidationError{{Field: "type", Message: "type must be one of: event todo"}})
+		return
+	}
+
+	user, err := h.calendarService.GetFeedUser(mux.Vars(r)["token"])
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+
+	cal := ical.NewWriter(w)
+	cal.Begin("VCALENDAR")
+	cal.Raw("VERSION", "2.0")
+	cal.Raw("PRODID", "-//Task Management//Tasks//EN")
+	cal.Raw("CALSCALE", "GREGORIAN")
+	cal.Raw("METHOD", "PUBLISH")
+	cal.Text("X-WR-CALNAME", "Tasks - "+user.Name)
+
+	err = h.calendarService.GetFeedTasks(user.ID, func(task *model.Task) error {
+		writeCalendarTask(cal, task, kind)
+		return cal.Err()
+	})
+	if err == nil {
+		cal.End("VCALENDAR")
+		err = cal.Err()
+	}
+	if err != nil {
+		// Header sudah terkirim, feed hanya bisa dihentikan
+		log.Printf("Failed to write calendar feed for user %d: %v", user.ID, err)
+	}
+}
+
+// writeCalendarTask menulis satu task sebagai VEVENT atau VTODO. Event
+// dimulai dari start_at jika ada dan berakhir pada due_at; tanpa start_at
+// event berdurasi nol pada due_at.
+func writeCalendarTask(cal *ical.Writer, task *model.Task, kind string) {
+	component := "VEVENT"
+	if kind == model.CalendarFeedTodo {
+		component = "VTODO"
+	}
+
+	modified := task.CreatedAt
+	if task.UpdatedAt != nil {
+		modified = *task.UpdatedAt
+	}
+
+	cal.Begin(component)
+	cal.Raw("UID", fmt.Sprintf("task-%d@task-management", task.ID))
+	cal.Time("DTSTAMP", modified)
+	cal.Time("LAST-MODIFIED", modified)
+	cal.Text("SUMMARY", task.Title)
+	if task.Description != nil && *task.Description != "" {
+		cal.Text("DESCRIPTION", *task.Description)
+	}
+
+	if component == "VTODO" {
+		if task.StartAt != nil {
+			cal.Time("DTSTART", *task.StartAt)
+		}
+		cal.Time("DUE", *task.DueAt)
+		switch {
+		case task.IsDone:
+			cal.Raw("STATUS", "COMPLETED")
+		case task.Status == model.TaskStatusInProgress:
+			cal.Raw("STATUS", "IN-PROCESS")
+		default:
+			cal.Raw("STATUS", "NEEDS-ACTION")
+		}
+	} else if task.StartAt != nil && task.StartAt.Before(*task.DueAt) {
+		cal.Time("DTSTART", *task.StartAt)
+		cal.Time("DTEND", *task.DueAt)
+	} else {
+		cal.Time("DTSTART", *task.DueAt)
+	}
+
+	if priority, ok := icalPriorities[task.Priority]; ok {
+		cal.Raw("PRIORITY", priority)
+	}
+	if len(task.Labels) > 0 {
+		names := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			names[i] = label.Name
+		}
+		cal.TextList("CATEGORIES", names)
+	}
+	cal.End(component)
+}
+
+// requestBaseURL menyusun scheme dan host dari request, termasuk di belakang
+// reverse proxy yang mengirim X-Forwarded-Proto
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// writeCalendarError memetakan error dari CalendarService ke HTTP response
+func writeCalendarError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrCalendarTokenNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
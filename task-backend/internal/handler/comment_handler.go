@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// GetComments menangani pengambilan komentar pada task
+func (h *CommentHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	page, limit := parsePageAndLimit(r)
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+
+	comments, err := h.commentService.GetTaskComments(taskID, claims.UserID, page, limit, isAdmin)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, comments)
+}
+
+// CreateComment menangani pembuatan komentar atau reply pada task
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.CommentCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	comment, err := h.commentService.CreateComment(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	response.Created(w, comment)
+}
+
+// UpdateComment menangani edit komentar milik sendiri
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, commentID, ok := parseCommentPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.CommentUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	comment, err := h.commentService.UpdateComment(taskID, commentID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, comment)
+}
+
+// DeleteComment menangani penghapusan komentar
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, commentID, ok := parseCommentPath(w, r)
+	if !ok {
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	if err := h.commentService.DeleteComment(taskID, commentID, claims.UserID, isAdmin); err != nil {
+		writeCommentError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgCommentDeleted)
+}
+
+// parseCommentPath membaca task ID dan comment ID dari path, menulis 400 jika tidak valid
+func parseCommentPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(vars["commentId"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid comment ID")
+		return 0, 0, false
+	}
+
+	return taskID, commentID, true
+}
+
+// writeCommentError memetakan error dari CommentService ke HTTP response
+func writeCommentError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrTaskNotFound, model.ErrCommentNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrInvalidParentComment:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type LabelHandler struct {
+	labelService service.LabelService
+}
+
+func NewLabelHandler(labelService service.LabelService) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+	}
+}
+
+// GetLabels menangani pengambilan semua labels milik user
+func (h *LabelHandler) GetLabels(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	labels, err := h.labelService.GetUserLabels(claims.UserID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, labels)
+}
+
+// CreateLabel menangani pembuatan label baru
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	var req model.LabelCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(claims.UserID, &req)
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	response.Created(w, label)
+}
+
+// UpdateLabel menangani update label
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	labelID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid label ID")
+		return
+	}
+
+	var req model.LabelUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(labelID, claims.UserID, &req)
+	if err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, label)
+}
+
+// DeleteLabel menangani penghapusan label
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	labelID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid label ID")
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(labelID, claims.UserID); err != nil {
+		writeLabelError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgLabelDeleted)
+}
+
+// writeLabelError memetakan error dari LabelService ke HTTP response
+func writeLabelError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrLabelNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrLabelAlreadyExists:
+		response.Error(w, http.StatusConflict, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type ProjectHandler struct {
+	projectService service.ProjectService
+}
+
+func NewProjectHandler(projectService service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+	}
+}
+
+// GetProjects menangani pengambilan projects milik user
+func (h *ProjectHandler) GetProjects(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	projects, err := h.projectService.GetUserProjects(claims.UserID, includeArchived)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, projects)
+}
+
+// CreateProject menangani pembuatan project baru
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	var req model.ProjectCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	project, err := h.projectService.CreateProject(claims.UserID, &req)
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Created(w, project)
+}
+
+// GetProjectByID menangani pengambilan project berdasarkan ID
+func (h *ProjectHandler) GetProjectByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	projectID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	project, err := h.projectService.GetProjectByID(projectID, claims.UserID, isAdmin)
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, project)
+}
+
+// UpdateProject menangani update project, termasuk archive/unarchive
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	projectID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req model.ProjectUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	project, err := h.projectService.UpdateProject(projectID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, project)
+}
+
+// DeleteProject menangani penghapusan project
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	projectID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	if err := h.projectService.DeleteProject(projectID, claims.UserID, isAdmin); err != nil {
+		writeProjectError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgProjectDeleted)
+}
+
+// writeProjectError memetakan error dari ProjectService ke HTTP response
+func writeProjectError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrProjectNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrWorkflowNotFound:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
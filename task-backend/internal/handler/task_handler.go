@@ -1,14 +1,23 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/Mahathirrr/task-management-backend/internal/middleware"
 	"github.com/Mahathirrr/task-management-backend/internal/model"
 	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/filterql"
+	"github.com/Mahathirrr/task-management-backend/pkg/patch"
 	"github.com/Mahathirrr/task-management-backend/pkg/response"
 	"github.com/Mahathirrr/task-management-backend/pkg/validator"
 )
@@ -47,10 +56,11 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	// Create task
 	task, err := h.taskService.CreateTask(claims.UserID, &req)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		writeTaskError(w, err)
 		return
 	}
 
+	setTaskETag(w, task)
 	response.Created(w, task)
 }
 
@@ -64,18 +74,25 @@ func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse query parameters
-	page, limit := parsePageAndLimit(r)
-	status := r.URL.Query().Get("status")
-	search := r.URL.Query().Get("search")
+	filter, validationErrors := parseTaskFilter(r, claims.UserID)
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+	page, validationErrors := parseTaskPage(r, filter.Sort)
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
 
 	var tasksResp *model.TasksResponse
 	var err error
 
 	// Admin can see all tasks, users only their own
 	if claims.Role == string(model.UserRoleAdmin) {
-		tasksResp, err = h.taskService.GetAllTasks(page, limit, status, search)
+		tasksResp, err = h.taskService.GetAllTasks(page, filter)
 	} else {
-		tasksResp, err = h.taskService.GetUserTasks(claims.UserID, page, limit, status, search)
+		tasksResp, err = h.taskService.GetUserTasks(claims.UserID, page, filter)
 	}
 
 	if err != nil {
@@ -86,6 +103,39 @@ func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, tasksResp)
 }
 
+// GetBoard menangani pengambilan task per kolom status untuk kanban board.
+// Filter sama dengan list tasks, limit berlaku per kolom.
+func (h *TaskHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	filter, validationErrors := parseTaskFilter(r, claims.UserID)
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	limit := model.DefaultBoardLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= model.MaxBoardLimit {
+			limit = l
+		}
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	board, err := h.taskService.GetBoard(claims.UserID, filter, limit, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, board)
+}
+
 // GetTaskByID menangani pengambilan task berdasarkan ID
 func (h *TaskHandler) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 	// Get user from context
@@ -107,17 +157,11 @@ func (h *TaskHandler) GetTaskByID(w http.ResponseWriter, r *http.Request) {
 	isAdmin := claims.Role == string(model.UserRoleAdmin)
 	task, err := h.taskService.GetTaskByID(taskID, claims.UserID, isAdmin)
 	if err != nil {
-		switch err.Error() {
-		case model.ErrTaskNotFound:
-			response.Error(w, http.StatusNotFound, err.Error())
-		case model.ErrForbidden:
-			response.Error(w, http.StatusForbidden, err.Error())
-		default:
-			response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
-		}
+		writeTaskError(w, err)
 		return
 	}
 
+	setTaskETag(w, task)
 	response.JSON(w, http.StatusOK, task)
 }
 
@@ -149,22 +193,309 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		response.ValidationError(w, validationErrors)
 		return
 	}
+	req.IfMatch = parseIfMatch(r)
+
+	// Update task; scope=future juga mengupdate occurrence berikutnya
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	var task *model.Task
+	switch model.RecurrenceScope(r.URL.Query().Get("scope")) {
+	case "", model.RecurrenceScopeThis:
+		task, err = h.taskService.UpdateTask(taskID, claims.UserID, &req, isAdmin)
+	case model.RecurrenceScopeFuture:
+		task, err = h.taskService.UpdateTaskSeries(taskID, claims.UserID, &req, isAdmin)
+	default:
+		response.Error(w, http.StatusBadRequest, "Invalid scope, must be this or future")
+		return
+	}
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	response.JSON(w, http.StatusOK, task)
+}
+
+// GenerateOccurrences menangani pembuatan occurrence berikutnya dari task berulang
+func (h *TaskHandler) GenerateOccurrences(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.OccurrencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate input
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	tasks, err := h.taskService.GenerateOccurrences(taskID, claims.UserID, req.Count, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, tasks)
+}
+
+// AddDependency menangani penambahan blocker pada task
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.TaskDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate input
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	task, err := h.taskService.AddDependency(taskID, req.BlockerID, claims.UserID, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	response.JSON(w, http.StatusCreated, task)
+}
+
+// RemoveDependency menangani penghapusan blocker dari task
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+	blockerID, err := strconv.Atoi(vars["blockerId"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid blocker ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	if err := h.taskService.RemoveDependency(taskID, blockerID, claims.UserID, isAdmin); err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgDependencyRemoved)
+}
+
+// MoveTask menangani perpindahan task di board ke status dan posisi baru
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.TaskMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate input
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+	req.IfMatch = parseIfMatch(r)
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	task, err := h.taskService.MoveTask(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	response.JSON(w, http.StatusOK, task)
+}
+
+// ToggleChecklistItem menangani centang checkbox task list pada description
+func (h *TaskHandler) ToggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID and checkbox index from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+	index, err := strconv.Atoi(vars["index"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid checklist index")
+		return
+	}
+
+	var req model.TaskChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate input
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+	req.IfMatch = parseIfMatch(r)
 
-	// Update task
 	isAdmin := claims.Role == string(model.UserRoleAdmin)
-	task, err := h.taskService.UpdateTask(taskID, claims.UserID, &req, isAdmin)
+	task, err := h.taskService.ToggleChecklistItem(taskID, index, claims.UserID, &req, isAdmin)
 	if err != nil {
-		switch err.Error() {
-		case model.ErrTaskNotFound:
-			response.Error(w, http.StatusNotFound, err.Error())
-		case model.ErrForbidden:
-			response.Error(w, http.StatusForbidden, err.Error())
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	response.JSON(w, http.StatusOK, task)
+}
+
+// PatchTask menangani update sebagian task dengan JSON Merge Patch
+// (RFC 7396) atau JSON Patch (RFC 6902)
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != patch.MergePatchType && mediaType != patch.JSONPatchType) {
+		w.Header().Set("Accept-Patch", patch.MergePatchType+", "+patch.JSONPatchType)
+		response.Error(w, http.StatusUnsupportedMediaType, "Content-Type must be "+patch.MergePatchType+" or "+patch.JSONPatchType)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid patch document")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	task, err := h.taskService.GetTaskByID(taskID, claims.UserID, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+	if !task.MatchesVersion(parseIfMatch(r)) {
+		writeTaskError(w, errors.New(model.ErrTaskModified))
+		return
+	}
+
+	// Patch diterapkan ke representasi task saat ini
+	doc, err := json.Marshal(model.NewTaskPatch(task))
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+
+	patched, err := patch.Apply(mediaType, doc, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrTestFailed):
+			response.Error(w, http.StatusConflict, "Patch test operation failed")
+		case errors.Is(err, patch.ErrCannotApply):
+			response.Error(w, http.StatusUnprocessableEntity, "Patch cannot be applied to the task")
 		default:
-			response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+			response.Error(w, http.StatusBadRequest, "Invalid patch document")
 		}
 		return
 	}
 
+	var req model.TaskPatch
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		response.Error(w, http.StatusUnprocessableEntity, "Patched task contains unknown or invalid fields")
+		return
+	}
+	req.Version = task.Version
+
+	// Validate patched result
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	task, err = h.taskService.PatchTask(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
 	response.JSON(w, http.StatusOK, task)
 }
 
@@ -185,24 +516,249 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Subtasks dipindahkan ke parent secara default agar tidak ikut terhapus
+	mode := model.SubtaskDeleteMode(r.URL.Query().Get("subtasks"))
+	if mode == "" {
+		mode = model.SubtaskDeleteReparent
+	}
+	if mode != model.SubtaskDeleteCascade && mode != model.SubtaskDeleteReparent {
+		response.Error(w, http.StatusBadRequest, "subtasks must be one of: cascade reparent")
+		return
+	}
+
 	// Delete task
 	isAdmin := claims.Role == string(model.UserRoleAdmin)
-	err = h.taskService.DeleteTask(taskID, claims.UserID, isAdmin)
+	err = h.taskService.DeleteTask(taskID, claims.UserID, isAdmin, mode)
 	if err != nil {
-		switch err.Error() {
-		case model.ErrTaskNotFound:
-			response.Error(w, http.StatusNotFound, err.Error())
-		case model.ErrForbidden:
-			response.Error(w, http.StatusForbidden, err.Error())
-		default:
-			response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
-		}
+		writeTaskError(w, err)
 		return
 	}
 
 	response.Success(w, model.MsgTaskDeleted)
 }
 
+// GetSubtasks menangani pengambilan subtasks langsung dari sebuah task
+func (h *TaskHandler) GetSubtasks(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	subtasks, err := h.taskService.GetSubtasks(taskID, claims.UserID, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	if subtasks == nil {
+		subtasks = []model.Task{}
+	}
+
+	response.JSON(w, http.StatusOK, subtasks)
+}
+
+// CreateSubtask menangani pembuatan subtask di bawah sebuah task
+func (h *TaskHandler) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.TaskCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	subtask, err := h.taskService.CreateSubtask(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.Created(w, subtask)
+}
+
+// GetTaskActivity menangani pengambilan riwayat perubahan task
+func (h *TaskHandler) GetTaskActivity(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	page, limit := parsePageAndLimit(r)
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+
+	activities, err := h.taskService.GetTaskActivity(taskID, claims.UserID, page, limit, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, activities)
+}
+
+// writeTaskError memetakan error dari TaskService ke HTTP response
+func writeTaskError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrTaskNotFound, model.ErrBlockerNotFound, model.ErrDependencyNotFound, model.ErrMoveNeighbourNotFound,
+		model.ErrChecklistItemNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrInvalidDateRange, model.ErrMaxTaskDepth, model.ErrInvalidLabels, model.ErrInvalidProject, model.ErrAssigneeNotFound,
+		model.ErrInvalidRecurrence, model.ErrRecurrenceRequiresDueDate, model.ErrRecurrenceScope, model.ErrTaskNotRecurring,
+		model.ErrDependencySelf, model.ErrInvalidMove:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case model.ErrInvalidStatus:
+		response.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case model.ErrInvalidTransition, model.ErrParentTaskTrashed, model.ErrDependencyCycle, model.ErrTaskBlocked, model.ErrBoardConflict:
+		response.Error(w, http.StatusConflict, err.Error())
+	case model.ErrTaskModified:
+		response.Error(w, http.StatusPreconditionFailed, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
+
+// BulkUpdateTasks menangani satu operasi untuk banyak task sekaligus
+func (h *TaskHandler) BulkUpdateTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	var req model.TaskBulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	// Validate input
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	resp, err := h.taskService.BulkUpdateTasks(claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// GetTrash menangani list task di trash
+func (h *TaskHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	page, limit := parsePageAndLimit(r)
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	tasksResp, err := h.taskService.GetTrash(claims.UserID, page, limit, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, tasksResp)
+}
+
+// RestoreTask menangani pengembalian task dari trash
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	// Get task ID from URL
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	task, err := h.taskService.RestoreTask(taskID, claims.UserID, isAdmin)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	setTaskETag(w, task)
+	response.JSON(w, http.StatusOK, task)
+}
+
+// setTaskETag mengirim version task sebagai ETag
+func setTaskETag(w http.ResponseWriter, task *model.Task) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, task.Version))
+}
+
+// parseIfMatch membaca version dari header If-Match. nil berarti tanpa
+// precondition (header kosong atau "*"). Weak ETag diabaikan karena If-Match
+// memakai strong comparison, sehingga tidak pernah cocok.
+func parseIfMatch(r *http.Request) []int {
+	header := strings.TrimSpace(strings.Join(r.Header.Values("If-Match"), ","))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
 // parsePageAndLimit parses page and limit query parameters
 func parsePageAndLimit(r *http.Request) (int, int) {
 	page := 1
@@ -221,4 +777,164 @@ func parsePageAndLimit(r *http.Request) (int, int) {
 	}
 
 	return page, limit
-}
\ No newline at end of file
+}
+
+// parseTaskPage parses pagination query parameters for task listings.
+// When cursor is given, page is ignored and the total is only counted
+// if include_total=true.
+func parseTaskPage(r *http.Request, sort string) (model.TaskPage, []model.ValidationError) {
+	query := r.URL.Query()
+
+	var page model.TaskPage
+	page.Page, page.Limit = parsePageAndLimit(r)
+
+	var errors []model.ValidationError
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := model.DecodeTaskCursor(value)
+		if err != nil {
+			errors = append(errors, model.ValidationError{
+				Field:   "cursor",
+				Message: "cursor is invalid",
+			})
+		} else if !model.SupportsCursor(sort) {
+			errors = append(errors, model.ValidationError{
+				Field:   "cursor",
+				Message: "cursor can only be used with sort: -created_at created_at",
+			})
+		} else {
+			page.Cursor = cursor
+			page.Page = 0
+		}
+	}
+
+	page.IncludeTotal = page.Cursor == nil
+	if value := query.Get("include_total"); value != "" {
+		includeTotal, err := strconv.ParseBool(value)
+		if err != nil {
+			errors = append(errors, model.ValidationError{
+				Field:   "include_total",
+				Message: "include_total must be true or false",
+			})
+		} else {
+			page.IncludeTotal = includeTotal
+		}
+	}
+
+	return page, errors
+}
+
+// parseTaskFilter parses filter query parameters for task listings.
+// userID is used to resolve assigned_to=me.
+func parseTaskFilter(r *http.Request, userID int) (model.TaskFilter, []model.ValidationError) {
+	query := r.URL.Query()
+	filter := model.TaskFilter{
+		Status:   query.Get("status"),
+		Priority: query.Get("priority"),
+		Search:   query.Get("search"),
+		Overdue:  query.Get("overdue") == "true",
+		Sort:     query.Get("sort"),
+	}
+
+	if labels := query.Get("labels"); labels != "" {
+		for _, name := range strings.Split(labels, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				filter.Labels = append(filter.Labels, name)
+			}
+		}
+	}
+	filter.LabelMode = query.Get("labels_mode")
+	filter.IncludeArchived = query.Get("include_archived") == "true"
+
+	var errors []model.ValidationError
+	if filter.Priority != "" && !model.TaskPriority(filter.Priority).IsValid() {
+		errors = append(errors, model.ValidationError{
+			Field:   "priority",
+			Message: "priority must be one of: low medium high urgent",
+		})
+	}
+	if projectID := query.Get("project_id"); projectID != "" {
+		id, err := strconv.Atoi(projectID)
+		if err != nil || id <= 0 {
+			errors = append(errors, model.ValidationError{
+				Field:   "project_id",
+				Message: "project_id must be a positive integer",
+			})
+		} else {
+			filter.ProjectID = &id
+		}
+	}
+	if assignedTo := query.Get("assigned_to"); assignedTo != "" {
+		if assignedTo == "me" {
+			filter.AssignedTo = &userID
+		} else if id, err := strconv.Atoi(assignedTo); err == nil && id > 0 {
+			filter.AssignedTo = &id
+		} else {
+			errors = append(errors, model.ValidationError{
+				Field:   "assigned_to",
+				Message: "assigned_to must be 'me' or a positive user ID",
+			})
+		}
+	}
+	if filter.LabelMode != "" && filter.LabelMode != model.LabelMatchAny && filter.LabelMode != model.LabelMatchAll {
+		errors = append(errors, model.ValidationError{
+			Field:   "labels_mode",
+			Message: "labels_mode must be one of: any all",
+		})
+	}
+	// Selain nilai sort bawaan, sort bisa berupa daftar field seperti -updated_at,title
+	if filter.Sort != "" && !model.IsValidTaskSort(filter.Sort) {
+		sortFields, err := filterql.ParseSort(filter.Sort, model.TaskSortFields)
+		if err != nil {
+			errors = append(errors, model.ValidationError{
+				Field:   "sort",
+				Message: "sort must be one of: -created_at created_at priority due_at relevance, or a list of fields: " + err.Error(),
+			})
+		}
+		filter.SortFields = sortFields
+	}
+	if expr := query.Get("filter"); expr != "" {
+		node, err := filterql.Parse(expr, model.TaskFilterFields)
+		if err != nil {
+			errors = append(errors, model.ValidationError{
+				Field:   "filter",
+				Message: err.Error(),
+			})
+		}
+		filter.Expr = node
+	}
+	// Hasil pencarian diurutkan berdasarkan relevansi kecuali sort diisi
+	if filter.Search != "" && filter.Sort == "" {
+		filter.Sort = model.TaskSortRelevance
+	} else if filter.Search == "" && filter.Sort == model.TaskSortRelevance {
+		errors = append(errors, model.ValidationError{
+			Field:   "sort",
+			Message: "sort relevance requires search",
+		})
+	}
+
+	dateParams := []struct {
+		name   string
+		target **time.Time
+	}{
+		{"due_before", &filter.DueBefore},
+		{"due_after", &filter.DueAfter},
+	}
+
+	for _, param := range dateParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errors = append(errors, model.ValidationError{
+				Field:   param.name,
+				Message: param.name + " must be a valid RFC3339 timestamp",
+			})
+			continue
+		}
+		*param.target = &t
+	}
+
+	return filter, errors
+}
@@ -0,0 +1,493 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+)
+
+// maxImportSize adalah ukuran maksimum body import
+const maxImportSize = 10 << 20
+
+// taskCSVColumns adalah kolom CSV export. Import membaca kolom berdasarkan
+// nama header sehingga urutan bebas dan kolom lain diabaikan.
+var taskCSVColumns = []string{
+	"id", "parent_task_id", "project_id", "assignee_id", "title", "description",
+	"status", "priority", "start_at", "due_at", "label_ids", "created_at",
+}
+
+// csvFormulaPrefixes adalah karakter awal yang dibaca spreadsheet sebagai
+// formula. Nilai yang diawali karakter ini (atau tanda kutip escape itu
+// sendiri) diberi awalan ' saat export dan awalan tersebut dibuang saat import.
+const csvFormulaPrefixes = "=+-@\t\r'"
+
+// transferContentTypes adalah Content-Type untuk setiap format
+var transferContentTypes = map[string]string{
+	model.TransferFormatCSV:    "text/csv; charset=utf-8",
+	model.TransferFormatJSON:   "application/json",
+	model.TransferFormatNDJSON: "application/x-ndjson",
+}
+
+// ExportTasks menangani export tasks dengan filter yang sama seperti list
+// tasks. Task ditulis langsung ke response per batch tanpa dimuat semua.
+func (h *TaskHandler) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = model.TransferFormatJSON
+	}
+	contentType, ok := transferContentTypes[format]
+	if !ok {
+		response.ValidationError(w, []model.ValidationError{{Field: "format", Message: "format must be one of: csv json ndjson"}})
+		return
+	}
+
+	filter, validationErrors := parseTaskFilter(r, claims.UserID)
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "tasks." + format}))
+
+	writer := newTaskExportWriter(w, format)
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	err := h.taskService.ExportTasks(claims.UserID, filter, isAdmin, func(task *model.Task) error {
+		return writer.Write(model.NewTaskRecord(task))
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	log.Printf("Failed to export tasks for user %d: %v", claims.UserID, err)
+	if writer.written == 0 {
+		// Belum ada yang ditulis sehingga error masih bisa dikirim biasa
+		w.Header().Del("Content-Disposition")
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+	// Status 200 sudah terkirim. Koneksi diputus tanpa penutup response agar
+	// client melihat export sebagai gagal, bukan file yang lengkap.
+	panic(http.ErrAbortHandler)
+}
+
+// taskExportWriter menulis TaskRecord ke response sesuai format
+type taskExportWriter struct {
+	w       io.Writer
+	format  string
+	csv     *csv.Writer
+	written int
+}
+
+func newTaskExportWriter(w io.Writer, format string) *taskExportWriter {
+	writer := &taskExportWriter{w: w, format: format}
+	if format == model.TransferFormatCSV {
+		writer.csv = csv.NewWriter(w)
+	}
+	return writer
+}
+
+// Write menulis satu record. Header CSV dan pembuka array JSON ditulis
+// bersama record pertama.
+func (e *taskExportWriter) Write(record model.TaskRecord) error {
+	defer func() { e.written++ }()
+
+	switch e.format {
+	case model.TransferFormatCSV:
+		if e.written == 0 {
+			if err := e.csv.Write(taskCSVColumns); err != nil {
+				return err
+			}
+		}
+		if err := e.csv.Write(taskCSVRow(record)); err != nil {
+			return err
+		}
+		// Flush per record agar data tidak menumpuk di buffer csv.Writer
+		e.csv.Flush()
+		return e.csv.Error()
+	case model.TransferFormatNDJSON:
+		return json.NewEncoder(e.w).Encode(record)
+	default:
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		separator := ","
+		if e.written == 0 {
+			separator = "["
+		}
+		_, err = io.WriteString(e.w, separator+string(data))
+		return err
+	}
+}
+
+// Close menyelesaikan output, termasuk untuk export tanpa task
+func (e *taskExportWriter) Close() error {
+	switch e.format {
+	case model.TransferFormatCSV:
+		if e.written == 0 {
+			if err := e.csv.Write(taskCSVColumns); err != nil {
+				return err
+			}
+		}
+		e.csv.Flush()
+		return e.csv.Error()
+	case model.TransferFormatNDJSON:
+		return nil
+	default:
+		closing := "]\n"
+		if e.written == 0 {
+			closing = "[]\n"
+		}
+		_, err := io.WriteString(e.w, closing)
+		return err
+	}
+}
+
+// taskCSVRow mengubah record menjadi baris CSV sesuai taskCSVColumns
+func taskCSVRow(record model.TaskRecord) []string {
+	formatInt := func(value *int) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.Itoa(*value)
+	}
+	formatTime := func(value *time.Time) string {
+		if value == nil {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	}
+
+	description := ""
+	if record.Description != nil {
+		description = *record.Description
+	}
+	labelIDs := make([]string, len(record.LabelIDs))
+	for i, id := range record.LabelIDs {
+		labelIDs[i] = strconv.Itoa(id)
+	}
+
+	row := []string{
+		strconv.Itoa(record.ID),
+		formatInt(record.ParentTaskID),
+		formatInt(record.ProjectID),
+		formatInt(record.AssigneeID),
+		record.Title,
+		description,
+		string(record.Status),
+		string(record.Priority),
+		formatTime(record.StartAt),
+		formatTime(record.DueAt),
+		strings.Join(labelIDs, ";"),
+		formatTime(record.CreatedAt),
+	}
+	for i, value := range row {
+		if value != "" && strings.IndexByte(csvFormulaPrefixes, value[0]) >= 0 {
+			row[i] = "'" + value
+		}
+	}
+	return row
+}
+
+// ImportTasks menangani import tasks dari CSV, JSON array, atau NDJSON.
+// Format diambil dari ?format= atau Content-Type. Dengan ?dry_run=true
+// semua baris divalidasi tanpa menyimpan task.
+func (h *TaskHandler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = importFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if _, ok := transferContentTypes[format]; !ok {
+		response.ValidationError(w, []model.ValidationError{{Field: "format", Message: "format must be one of: csv json ndjson"}})
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.ValidationError(w, []model.ValidationError{{Field: "dry_run", Message: "dry_run must be true or false"}})
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var rows []model.TaskImportRow
+	var err error
+	switch format {
+	case model.TransferFormatCSV:
+		rows, err = readCSVImport(r.Body)
+	case model.TransferFormatNDJSON:
+		rows, err = readNDJSONImport(r.Body)
+	default:
+		rows, err = readJSONImport(r.Body)
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(w, http.StatusRequestEntityTooLarge, model.ErrImportTooLarge)
+			return
+		}
+		if errors.Is(err, errTooManyImportRows) {
+			response.Error(w, http.StatusRequestEntityTooLarge, model.ErrImportTooLarge)
+			return
+		}
+		response.Error(w, http.StatusBadRequest, fmt.Sprintf("%s: %v", model.ErrInvalidImportFile, err))
+		return
+	}
+
+	// Validasi per baris dengan aturan yang sama seperti request create task
+	for i := range rows {
+		if len(rows[i].Errors) == 0 {
+			rows[i].Errors = validator.ValidateStruct(rows[i].Record)
+		}
+	}
+
+	resp, err := h.taskService.ImportTasks(claims.UserID, rows, dryRun)
+	if err != nil {
+		writeTaskError(w, err)
+		return
+	}
+
+	switch {
+	case len(resp.Errors) > 0:
+		response.JSON(w, http.StatusUnprocessableEntity, resp)
+	case dryRun:
+		response.JSON(w, http.StatusOK, resp)
+	default:
+		response.Created(w, resp)
+	}
+}
+
+// errTooManyImportRows dikembalikan reader jika baris melebihi MaxImportRows
+var errTooManyImportRows = errors.New("too many rows")
+
+// importFormatFromContentType menentukan format import dari Content-Type,
+// JSON jika tidak dikenali
+func importFormatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return model.TransferFormatCSV
+	case "application/x-ndjson", "application/ndjson":
+		return model.TransferFormatNDJSON
+	}
+	return model.TransferFormatJSON
+}
+
+// readJSONImport membaca JSON array berisi TaskRecord. Nilai dengan tipe
+// salah menjadi error baris, sedangkan JSON yang rusak menggagalkan import.
+func readJSONImport(body io.Reader) ([]model.TaskImportRow, error) {
+	decoder := json.NewDecoder(body)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("expected a JSON array")
+	}
+
+	var rows []model.TaskImportRow
+	for decoder.More() {
+		if len(rows) == model.MaxImportRows {
+			return nil, errTooManyImportRows
+		}
+
+		row := model.TaskImportRow{Row: len(rows) + 1}
+		if err := decoder.Decode(&row.Record); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, err
+			}
+			row.Errors = []model.ValidationError{jsonFieldError(typeErr)}
+		}
+		rows = append(rows, row)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// readNDJSONImport membaca satu TaskRecord per baris, baris kosong diabaikan
+func readNDJSONImport(body io.Reader) ([]model.TaskImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxImportSize)
+
+	var rows []model.TaskImportRow
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if len(rows) == model.MaxImportRows {
+			return nil, errTooManyImportRows
+		}
+
+		row := model.TaskImportRow{Row: len(rows) + 1}
+		if err := json.Unmarshal([]byte(line), &row.Record); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				row.Errors = []model.ValidationError{jsonFieldError(typeErr)}
+			} else {
+				row.Errors = []model.ValidationError{{Field: "row", Message: "row is not valid JSON"}}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
+
+// jsonFieldError mengubah error tipe JSON menjadi error field
+func jsonFieldError(err *json.UnmarshalTypeError) model.ValidationError {
+	return model.ValidationError{Field: err.Field, Message: err.Field + " must be a " + err.Type.String()}
+}
+
+// readCSVImport membaca CSV dengan baris pertama sebagai header. Kolom
+// title wajib ada, label_ids dipisahkan dengan ";".
+func readCSVImport(body io.Reader) ([]model.TaskImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("missing CSV header")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must contain a title column")
+	}
+
+	var rows []model.TaskImportRow
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == model.MaxImportRows {
+			return nil, errTooManyImportRows
+		}
+
+		row := model.TaskImportRow{Row: len(rows) + 1}
+		row.Record, row.Errors = parseCSVRecord(columns, cells)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// unescapeCSVCell membuang awalan ' yang ditambahkan taskCSVRow pada nilai
+// yang diawali karakter formula
+func unescapeCSVCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.IndexByte(csvFormulaPrefixes, value[1]) >= 0 {
+		return value[1:]
+	}
+	return value
+}
+
+// parseCSVRecord membaca satu baris CSV menjadi TaskRecord. Sel kosong
+// berarti field tidak diisi.
+func parseCSVRecord(columns map[string]int, cells []string) (model.TaskRecord, []model.ValidationError) {
+	var record model.TaskRecord
+	var errs []model.ValidationError
+
+	cell := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(cells) {
+			return ""
+		}
+		return unescapeCSVCell(strings.TrimSpace(cells[i]))
+	}
+	parseInt := func(name string) *int {
+		value := cell(name)
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, model.ValidationError{Field: name, Message: name + " must be a number"})
+			return nil
+		}
+		return &n
+	}
+	parseTime := func(name string) *time.Time {
+		value := cell(name)
+		if value == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, model.ValidationError{Field: name, Message: name + " must be an RFC3339 timestamp"})
+			return nil
+		}
+		return &t
+	}
+
+	if id := parseInt("id"); id != nil {
+		record.ID = *id
+	}
+	record.ParentTaskID = parseInt("parent_task_id")
+	record.ProjectID = parseInt("project_id")
+	record.AssigneeID = parseInt("assignee_id")
+	record.Title = cell("title")
+	if i, ok := columns["description"]; ok && i < len(cells) && cells[i] != "" {
+		// Description tidak di-trim agar Markdown tetap utuh
+		description := unescapeCSVCell(cells[i])
+		record.Description = &description
+	}
+	record.Status = model.TaskStatus(cell("status"))
+	record.Priority = model.TaskPriority(cell("priority"))
+	record.StartAt = parseTime("start_at")
+	record.DueAt = parseTime("due_at")
+
+	if value := cell("label_ids"); value != "" {
+		for _, part := range strings.Split(value, ";") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				errs = append(errs, model.ValidationError{Field: "label_ids", Message: "label_ids must be numbers separated by ;"})
+				break
+			}
+			record.LabelIDs = append(record.LabelIDs, id)
+		}
+	}
+
+	return record, errs
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type TemplateHandler struct {
+	templateService service.TemplateService
+}
+
+func NewTemplateHandler(templateService service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetTemplates menangani pengambilan semua template milik user
+func (h *TemplateHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	templates, err := h.templateService.GetUserTemplates(claims.UserID)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, templates)
+}
+
+// GetTemplateByID menangani pengambilan satu template
+func (h *TemplateHandler) GetTemplateByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	template, err := h.templateService.GetTemplateByID(templateID, claims.UserID)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, template)
+}
+
+// CreateTemplate menangani pembuatan template baru
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	var req model.TaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(claims.UserID, &req)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Created(w, template)
+}
+
+// UpdateTemplate menangani penggantian isi template
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	var req model.TaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(templateID, claims.UserID, &req)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, template)
+}
+
+// DeleteTemplate menangani penghapusan template
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(templateID, claims.UserID); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgTemplateDeleted)
+}
+
+// InstantiateTemplate menangani pembuatan task dari template
+func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	// Body boleh kosong jika template tidak butuh variables
+	var req model.TemplateInstantiateRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+			return
+		}
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	resp, err := h.templateService.InstantiateTemplate(templateID, claims.UserID, &req)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	response.Created(w, resp)
+}
+
+// writeTemplateError memetakan error dari TemplateService ke HTTP response.
+// Error pembuatan task saat instantiate diteruskan ke writeTaskError.
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrTemplateNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrTemplateVariableMissing, model.ErrTemplateTitleInvalid:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		writeTaskError(w, err)
+	}
+}
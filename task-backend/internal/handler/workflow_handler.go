@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type WorkflowHandler struct {
+	workflowService service.WorkflowService
+}
+
+func NewWorkflowHandler(workflowService service.WorkflowService) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+// GetWorkflows menangani pengambilan semua workflow
+func (h *WorkflowHandler) GetWorkflows(w http.ResponseWriter, r *http.Request) {
+	workflows, err := h.workflowService.GetAllWorkflows()
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, workflows)
+}
+
+// GetWorkflowByID menangani pengambilan workflow berdasarkan ID
+func (h *WorkflowHandler) GetWorkflowByID(w http.ResponseWriter, r *http.Request) {
+	workflowID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid workflow ID")
+		return
+	}
+
+	workflow, err := h.workflowService.GetWorkflowByID(workflowID)
+	if err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, workflow)
+}
+
+// CreateWorkflow menangani pembuatan workflow baru (admin only)
+func (h *WorkflowHandler) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	var req model.WorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	workflow, err := h.workflowService.CreateWorkflow(&req)
+	if err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+
+	response.Created(w, workflow)
+}
+
+// UpdateWorkflow menangani penggantian status dan transisi workflow (admin only)
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflowID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid workflow ID")
+		return
+	}
+
+	var req model.WorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	workflow, err := h.workflowService.UpdateWorkflow(workflowID, &req)
+	if err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, workflow)
+}
+
+// DeleteWorkflow menangani penghapusan workflow (admin only)
+func (h *WorkflowHandler) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflowID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid workflow ID")
+		return
+	}
+
+	if err := h.workflowService.DeleteWorkflow(workflowID); err != nil {
+		writeWorkflowError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgWorkflowDeleted)
+}
+
+// writeWorkflowError memetakan error dari WorkflowService ke HTTP response
+func writeWorkflowError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrWorkflowNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrInvalidWorkflow:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case model.ErrDefaultWorkflow:
+		response.Error(w, http.StatusConflict, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/response"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+	"github.com/gorilla/mux"
+)
+
+type WorklogHandler struct {
+	worklogService service.WorklogService
+}
+
+func NewWorklogHandler(worklogService service.WorklogService) *WorklogHandler {
+	return &WorklogHandler{
+		worklogService: worklogService,
+	}
+}
+
+// StartTimer menangani start timer pada task, body note bersifat opsional
+func (h *WorklogHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.TimerStartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	worklog, err := h.worklogService.StartTimer(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.Created(w, worklog)
+}
+
+// StopTimer menangani stop timer yang berjalan pada task
+func (h *WorklogHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	worklog, err := h.worklogService.StopTimer(taskID, claims.UserID, isAdmin)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, worklog)
+}
+
+// GetRunningTimer menangani pengambilan timer user yang sedang berjalan
+func (h *WorklogHandler) GetRunningTimer(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	worklog, err := h.worklogService.GetRunningTimer(claims.UserID)
+	if err != nil {
+		if err.Error() == model.ErrTimerNotRunning {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, worklog)
+}
+
+// GetWorklogs menangani pengambilan worklog task beserta totalnya
+func (h *WorklogHandler) GetWorklogs(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	period, validationErrors := parseWorklogRange(r)
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	worklogs, err := h.worklogService.GetTaskWorklogs(taskID, claims.UserID, period, isAdmin)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, worklogs)
+}
+
+// CreateWorklog menangani pencatatan worklog manual
+func (h *WorklogHandler) CreateWorklog(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	taskID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req model.WorklogCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if validationErrors := validator.ValidateStruct(req); len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	worklog, err := h.worklogService.CreateWorklog(taskID, claims.UserID, &req, isAdmin)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.Created(w, worklog)
+}
+
+// DeleteWorklog menangani penghapusan worklog
+func (h *WorklogHandler) DeleteWorklog(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	vars := mux.Vars(r)
+	taskID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	worklogID, err := strconv.Atoi(vars["worklogId"])
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid worklog ID")
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	if err := h.worklogService.DeleteWorklog(taskID, worklogID, claims.UserID, isAdmin); err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.Success(w, model.MsgWorklogDeleted)
+}
+
+// GetSummary menangani ringkasan waktu user per task. Admin dapat melihat
+// ringkasan user lain melalui query user_id.
+func (h *WorklogHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetUserFromContext(r)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, model.ErrUnauthorized)
+		return
+	}
+
+	period, validationErrors := parseWorklogRange(r)
+
+	targetUserID := claims.UserID
+	if value := r.URL.Query().Get("user_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   "user_id",
+				Message: "user_id must be a positive integer",
+			})
+		}
+		targetUserID = id
+	}
+
+	if len(validationErrors) > 0 {
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	isAdmin := claims.Role == string(model.UserRoleAdmin)
+	summary, err := h.worklogService.GetUserSummary(targetUserID, claims.UserID, period, isAdmin)
+	if err != nil {
+		writeWorklogError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, summary)
+}
+
+// parseWorklogRange membaca query from dan to dalam format RFC3339
+func parseWorklogRange(r *http.Request) (model.WorklogRange, []model.ValidationError) {
+	var period model.WorklogRange
+	var validationErrors []model.ValidationError
+
+	query := r.URL.Query()
+	params := []struct {
+		name   string
+		target **time.Time
+	}{
+		{"from", &period.From},
+		{"to", &period.To},
+	}
+
+	for _, param := range params {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			validationErrors = append(validationErrors, model.ValidationError{
+				Field:   param.name,
+				Message: param.name + " must be a valid RFC3339 timestamp",
+			})
+			continue
+		}
+		*param.target = &t
+	}
+
+	if period.From != nil && period.To != nil && !period.To.After(*period.From) {
+		validationErrors = append(validationErrors, model.ValidationError{
+			Field:   "to",
+			Message: "to must be after from",
+		})
+	}
+
+	return period, validationErrors
+}
+
+// writeWorklogError memetakan error dari WorklogService ke HTTP response
+func writeWorklogError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case model.ErrTaskNotFound, model.ErrWorklogNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case model.ErrForbidden:
+		response.Error(w, http.StatusForbidden, err.Error())
+	case model.ErrTimerRunning, model.ErrTimerNotRunning:
+		response.Error(w, http.StatusConflict, err.Error())
+	case model.ErrInvalidWorklogRange:
+		response.Error(w, http.StatusBadRequest, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, model.ErrInternalServer)
+	}
+}
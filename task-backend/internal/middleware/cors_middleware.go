@@ -13,13 +13,14 @@ func CORSMiddleware(corsConfig *config.CORSConfig) func(http.Handler) http.Handl
 	allowedOrigins := handlers.AllowedOrigins(corsConfig.AllowedOrigins)
 	allowedMethods := handlers.AllowedMethods(corsConfig.AllowedMethods)
 	allowedHeaders := handlers.AllowedHeaders(corsConfig.AllowedHeaders)
+	exposedHeaders := handlers.ExposedHeaders(corsConfig.ExposedHeaders)
 	maxAge := handlers.MaxAge(corsConfig.MaxAge)
 
 	// Conditionally add credentials support
 	if corsConfig.AllowCredentials {
 		allowCredentials := handlers.AllowCredentials()
-		return handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, allowCredentials, maxAge)
+		return handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, exposedHeaders, allowCredentials, maxAge)
 	}
 
-	return handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, maxAge)
+	return handlers.CORS(allowedOrigins, allowedMethods, allowedHeaders, exposedHeaders, maxAge)
 }
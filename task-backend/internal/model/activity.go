@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ActivityAction adalah jenis perubahan yang dicatat pada riwayat task
+type ActivityAction string
+
+const (
+	ActivityCreated  ActivityAction = "created"
+	ActivityUpdated  ActivityAction = "updated"
+	ActivityDeleted  ActivityAction = "deleted"
+	ActivityRestored ActivityAction = "restored"
+)
+
+// TaskActivity adalah satu entri riwayat task. Untuk ActivityUpdated, satu
+// entri dicatat per field yang berubah; Field kosong untuk aksi lainnya.
+type TaskActivity struct {
+	ID        int            `json:"id"`
+	TaskID    int            `json:"task_id"`
+	UserID    *int           `json:"user_id"` // actor, NULL jika user sudah dihapus
+	Action    ActivityAction `json:"action"`
+	Field     *string        `json:"field"`
+	OldValue  *string        `json:"old_value"`
+	NewValue  *string        `json:"new_value"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// ActivitiesResponse for paginated task activity response
+type ActivitiesResponse struct {
+	Activities []TaskActivity `json:"activities"`
+	Total      int            `json:"total"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Attachment adalah metadata file yang dilampirkan ke task. Isi file
+// disimpan di storage dengan StorageKey.
+type Attachment struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"task_id"`
+	UserID      int       `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,44 @@
+package model
+
+// DefaultBoardLimit dan MaxBoardLimit membatasi jumlah task per kolom board
+const (
+	DefaultBoardLimit = 50
+	MaxBoardLimit     = 200
+)
+
+// TaskMoveRequest for moving a task on the board. BeforeID adalah task yang
+// akan berada tepat di atas task yang dipindah dan AfterID task tepat di
+// bawahnya; tanpa keduanya task ditaruh di akhir kolom.
+type TaskMoveRequest struct {
+	Status   TaskStatus `json:"status" validate:"omitempty,task_status"`
+	BeforeID *int       `json:"before_id" validate:"omitempty,gt=0"`
+	AfterID  *int       `json:"after_id" validate:"omitempty,gt=0"`
+
+	// IfMatch berisi version dari header If-Match, nil jika tanpa precondition
+	IfMatch []int `json:"-"`
+}
+
+// TaskMove adalah perpindahan task yang sudah divalidasi oleh service.
+// Repository menghitung rank baru di dalam transaksi dari posisi tetangga
+// saat itu.
+type TaskMove struct {
+	TaskID   int
+	Version  int
+	Status   TaskStatus
+	BeforeID *int
+	AfterID  *int
+}
+
+// BoardColumn adalah satu kolom board berisi task dengan status yang sama
+// urut berdasarkan rank
+type BoardColumn struct {
+	Status  TaskStatus `json:"status"`
+	Name    string     `json:"name"`
+	Tasks   []Task     `json:"tasks"`
+	HasMore bool       `json:"has_more"`
+}
+
+// BoardResponse for tasks grouped by status
+type BoardResponse struct {
+	Columns []BoardColumn `json:"columns"`
+}
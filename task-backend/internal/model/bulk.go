@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// BulkOperation adalah operasi yang bisa diterapkan ke banyak task sekaligus
+type BulkOperation string
+
+const (
+	BulkUpdateStatus BulkOperation = "update_status"
+	BulkDelete       BulkOperation = "delete"
+	BulkMoveProject  BulkOperation = "move_project"
+	BulkAddLabel     BulkOperation = "add_label"
+)
+
+// MaxBulkTasks adalah jumlah task maksimum dalam satu bulk request
+const MaxBulkTasks = 100
+
+// TaskBulkRequest for applying one operation to many tasks
+type TaskBulkRequest struct {
+	TaskIDs   []int         `json:"task_ids" validate:"required,min=1,max=100,dive,gt=0"`
+	Operation BulkOperation `json:"operation" validate:"required,oneof=update_status delete move_project add_label"`
+
+	Status    *TaskStatus       `json:"status,omitempty" validate:"required_if=Operation update_status,omitempty,task_status"`
+	ProjectID *int              `json:"project_id,omitempty" validate:"required_if=Operation move_project,omitempty,gte=0"` // 0 mengeluarkan task dari project
+	LabelID   *int              `json:"label_id,omitempty" validate:"required_if=Operation add_label,omitempty,gt=0"`
+	Subtasks  SubtaskDeleteMode `json:"subtasks,omitempty" validate:"omitempty,oneof=cascade reparent"` // untuk delete, default reparent
+}
+
+// BulkResultStatus adalah hasil bulk operation untuk satu task
+type BulkResultStatus string
+
+const (
+	BulkResultOK        BulkResultStatus = "ok"
+	BulkResultNotFound  BulkResultStatus = "not_found"
+	BulkResultForbidden BulkResultStatus = "forbidden"
+	BulkResultInvalid   BulkResultStatus = "invalid" // ditolak validasi, misalnya transisi status
+)
+
+// BulkItemResult for result of a bulk operation on one task
+type BulkItemResult struct {
+	TaskID int              `json:"task_id"`
+	Status BulkResultStatus `json:"status"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// TaskBulkResponse for bulk operation response
+type TaskBulkResponse struct {
+	Operation BulkOperation    `json:"operation"`
+	Results   []BulkItemResult `json:"results"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
+
+// TaskBulkChange adalah perubahan yang sudah lolos authorization dan
+// diterapkan oleh TaskRepository dalam satu transaksi
+type TaskBulkChange struct {
+	Operation BulkOperation
+	TaskIDs   []int
+
+	Status    TaskStatus // BulkUpdateStatus
+	ProjectID *int       // BulkMoveProject, nil mengeluarkan task dari project
+	LabelID   int        // BulkAddLabel
+
+	// BulkDelete: ReparentIDs adalah task yang subtasks-nya dipindahkan ke
+	// parent task tersebut sebelum dihapus
+	DeletedAt   time.Time
+	ReparentIDs []int
+}
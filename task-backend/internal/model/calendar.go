@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// CalendarFeedLookback adalah rentang ke belakang dari sekarang untuk due
+// date task yang dimasukkan ke feed kalender
+const CalendarFeedLookback = 90 * 24 * time.Hour
+
+// Jenis component task pada feed kalender
+const (
+	CalendarFeedEvent = "event" // VEVENT, didukung hampir semua aplikasi kalender
+	CalendarFeedTodo  = "todo"  // VTODO
+)
+
+// CalendarToken adalah token rahasia feed kalender milik user. Hanya hash
+// token yang disimpan, token asli hanya dikembalikan saat dibuat.
+type CalendarToken struct {
+	UserID    int       `json:"-"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarTokenResponse berisi token baru beserta URL feed yang dapat
+// didaftarkan ke aplikasi kalender
+type CalendarTokenResponse struct {
+	Token     string    `json:"token"`
+	FeedURL   string    `json:"feed_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
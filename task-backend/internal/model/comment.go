@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Comment adalah komentar pada task. Reply selalu menempel ke komentar root
+// sehingga thread hanya satu level.
+type Comment struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	UserID    int        `json:"user_id"`
+	ParentID  *int       `json:"parent_id"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Replies   []Comment  `json:"replies,omitempty"`
+}
+
+// CommentCreateRequest for creating comment
+type CommentCreateRequest struct {
+	Body     string `json:"body" validate:"required,max=5000"`
+	ParentID *int   `json:"parent_id" validate:"omitempty,gt=0"`
+}
+
+// CommentUpdateRequest for updating comment
+type CommentUpdateRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+// CommentsResponse for paginated comments response, Total menghitung komentar root
+type CommentsResponse struct {
+	Comments []Comment `json:"comments"`
+	Total    int       `json:"total"`
+	Page     int       `json:"page"`
+	Limit    int       `json:"limit"`
+}
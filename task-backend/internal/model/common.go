@@ -30,20 +30,72 @@ type TokenResponse struct {
 
 // Common constants untuk response messages
 const (
-	ErrInvalidCredentials = "Invalid credentials"
-	ErrEmailAlreadyExists = "Email already exists"
-	ErrTaskNotFound       = "Task not found"
-	ErrUserNotFound       = "User not found"
-	ErrUnauthorized       = "Unauthorized"
-	ErrForbidden          = "Access denied"
-	ErrValidationFailed   = "Validation failed"
-	ErrInternalServer     = "Internal server error"
+	ErrInvalidCredentials        = "Invalid credentials"
+	ErrEmailAlreadyExists        = "Email already exists"
+	ErrTaskNotFound              = "Task not found"
+	ErrUserNotFound              = "User not found"
+	ErrUnauthorized              = "Unauthorized"
+	ErrForbidden                 = "Access denied"
+	ErrValidationFailed          = "Validation failed"
+	ErrInternalServer            = "Internal server error"
+	ErrInvalidDateRange          = "Start date must not be after due date"
+	ErrMaxTaskDepth              = "Maximum subtask depth exceeded"
+	ErrLabelNotFound             = "Label not found"
+	ErrLabelAlreadyExists        = "Label already exists"
+	ErrInvalidLabels             = "Labels must exist and belong to the task owner"
+	ErrProjectNotFound           = "Project not found"
+	ErrInvalidProject            = "Project must exist and belong to the task owner"
+	ErrAssigneeNotFound          = "Assignee not found"
+	ErrCommentNotFound           = "Comment not found"
+	ErrInvalidParentComment      = "Parent comment must belong to the same task"
+	ErrAttachmentNotFound        = "Attachment not found"
+	ErrAttachmentTooLarge        = "Attachment exceeds the maximum size"
+	ErrAttachmentTypeNotAllowed  = "Attachment type is not allowed"
+	ErrInvalidStatus             = "Status is not part of the task workflow"
+	ErrInvalidTransition         = "Status transition is not allowed by the task workflow"
+	ErrWorkflowNotFound          = "Workflow not found"
+	ErrInvalidWorkflow           = "Workflow must have one initial status and transitions between its own statuses"
+	ErrDefaultWorkflow           = "Default workflow cannot be deleted"
+	ErrInvalidRecurrence         = "Invalid recurrence rule"
+	ErrRecurrenceRequiresDueDate = "Recurring tasks require a due date"
+	ErrRecurrenceScope           = "Changing the recurrence of a recurring task requires scope=future"
+	ErrTaskNotRecurring          = "Task is not recurring"
+	ErrTaskModified              = "Task has been modified by another request"
+	ErrParentTaskTrashed         = "Parent task is in trash, restore the parent first"
+	ErrDependencySelf            = "Task cannot be blocked by itself"
+	ErrDependencyCycle           = "Dependency would create a cycle"
+	ErrBlockerNotFound           = "Blocker task not found"
+	ErrDependencyNotFound        = "Dependency not found"
+	ErrTaskBlocked               = "Task has incomplete blockers"
+	ErrMoveNeighbourNotFound     = "Neighbour task not found"
+	ErrInvalidMove               = "Task cannot be its own neighbour"
+	ErrChecklistItemNotFound     = "Checklist item not found"
+	ErrBoardConflict             = "Board order has changed, reload and try again"
+	ErrTimerRunning              = "A timer is already running, stop it first"
+	ErrTimerNotRunning           = "No timer is running"
+	ErrWorklogNotFound           = "Worklog not found"
+	ErrInvalidWorklogRange       = "Worklog must end after it starts and not in the future"
+	ErrTemplateNotFound          = "Template not found"
+	ErrTemplateVariableMissing   = "Template variable has no value"
+	ErrTemplateTitleInvalid      = "Rendered template title must be 1 to 255 characters"
+	ErrImportTooLarge            = "Import file is too large"
+	ErrInvalidImportFile         = "Import file could not be read"
+	ErrCalendarTokenNotFound     = "Calendar feed not found"
 
-	MsgLoginSuccess    = "Login successful"
-	MsgLogoutSuccess   = "Logout successful"
-	MsgRegisterSuccess = "Registration successful"
-	MsgTaskCreated     = "Task created successfully"
-	MsgTaskUpdated     = "Task updated successfully"
-	MsgTaskDeleted     = "Task deleted successfully"
-	MsgUserDeleted     = "User deleted successfully"
+	MsgLoginSuccess      = "Login successful"
+	MsgLogoutSuccess     = "Logout successful"
+	MsgRegisterSuccess   = "Registration successful"
+	MsgTaskCreated       = "Task created successfully"
+	MsgTaskUpdated       = "Task updated successfully"
+	MsgTaskDeleted       = "Task moved to trash"
+	MsgUserDeleted       = "User deleted successfully"
+	MsgLabelDeleted      = "Label deleted successfully"
+	MsgProjectDeleted    = "Project deleted successfully"
+	MsgCommentDeleted    = "Comment deleted successfully"
+	MsgAttachmentDeleted = "Attachment deleted successfully"
+	MsgWorkflowDeleted   = "Workflow deleted successfully"
+	MsgDependencyRemoved = "Dependency removed successfully"
+	MsgWorklogDeleted    = "Worklog deleted successfully"
+	MsgTemplateDeleted   = "Template deleted successfully"
+	MsgCalendarRevoked   = "Calendar feed revoked successfully"
 )
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type Label struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// LabelCreateRequest for creating label
+type LabelCreateRequest struct {
+	Name  string `json:"name" validate:"required,max=50,label_name"`
+	Color string `json:"color" validate:"required,hexcolor"`
+}
+
+// LabelUpdateRequest for updating label
+type LabelUpdateRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,max=50,label_name"`
+	Color *string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// Mode filter labels pada list tasks
+const (
+	LabelMatchAny = "any" // task punya minimal satu label
+	LabelMatchAll = "all" // task punya semua label
+)
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// TaskCursor menandai posisi pada list tasks yang diurutkan berdasarkan
+// (created_at, id). Backward true berarti halaman sebelum posisi ini.
+type TaskCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int       `json:"i"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+// Encode mengubah cursor menjadi string opaque untuk query ?cursor=
+func (c TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor membaca cursor dari string hasil Encode
+func DecodeTaskCursor(value string) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid cursor encoding")
+	}
+
+	var cursor TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 || cursor.CreatedAt.IsZero() {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+// SupportsCursor mengecek apakah sort bisa dipakai dengan cursor pagination,
+// yaitu hanya urutan berdasarkan created_at
+func SupportsCursor(sort string) bool {
+	return sort == "" || sort == TaskSortNewest || sort == TaskSortOldest
+}
+
+// TaskPage berisi parameter pagination list tasks. Cursor nil berarti
+// pagination page/limit.
+type TaskPage struct {
+	Page         int
+	Limit        int
+	Cursor       *TaskCursor
+	IncludeTotal bool
+}
+
+// TaskPageResult adalah satu halaman list tasks dari repository
+type TaskPageResult struct {
+	Tasks   []Task
+	Total   *int // nil jika tidak dihitung
+	HasNext bool
+	HasPrev bool
+
+	// MatchedComments berisi komentar paling relevan per task ID saat
+	// list difilter dengan search
+	MatchedComments map[int]string
+}
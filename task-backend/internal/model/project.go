@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type Project struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	WorkflowID  *int       `json:"workflow_id"`
+	Archived    bool       `json:"archived"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// ProjectCreateRequest for creating project
+type ProjectCreateRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description"`
+	WorkflowID  *int    `json:"workflow_id" validate:"omitempty,gt=0"`
+}
+
+// ProjectUpdateRequest for updating project
+type ProjectUpdateRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string `json:"description,omitempty"`
+	WorkflowID  *int    `json:"workflow_id,omitempty" validate:"omitempty,gte=0"` // 0 untuk kembali ke workflow default
+	Archived    *bool   `json:"archived,omitempty"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// TaskSeries menyimpan RRULE untuk sekumpulan task berulang. Setiap
+// occurrence adalah task biasa dengan SeriesID dan OccurrenceAt.
+type TaskSeries struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	Rule      string     `json:"rule"`
+	StartAt   time.Time  `json:"start_at"` // DTSTART, yaitu due date occurrence pertama
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// RecurrenceScope menentukan occurrence mana yang terkena update task berulang
+type RecurrenceScope string
+
+const (
+	RecurrenceScopeThis   RecurrenceScope = "this"   // hanya occurrence ini
+	RecurrenceScopeFuture RecurrenceScope = "future" // occurrence ini dan semua sesudahnya
+)
+
+// OccurrencesRequest for pre-generating upcoming occurrences
+type OccurrencesRequest struct {
+	Count int `json:"count" validate:"required,min=1,max=50"`
+}
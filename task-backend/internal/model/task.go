@@ -1,17 +1,80 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/filterql"
+	"github.com/Mahathirrr/task-management-backend/pkg/markdown"
+)
 
 type Task struct {
-	ID          int        `json:"id"`
-	UserID      int        `json:"user_id"`
-	Title       string     `json:"title"`
-	Description *string    `json:"description"`
-	Status      TaskStatus `json:"status"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	ID           int          `json:"id"`
+	UserID       int          `json:"user_id"`
+	ParentTaskID *int         `json:"parent_task_id"`
+	ProjectID    *int         `json:"project_id"`
+	AssigneeID   *int         `json:"assignee_id"`
+	SeriesID     *int         `json:"series_id"`
+	OccurrenceAt *time.Time   `json:"occurrence_at,omitempty"` // jadwal asli occurrence dalam series
+	Title        string       `json:"title"`
+	Description  *string      `json:"description"`
+	Status       TaskStatus   `json:"status"`
+	IsDone       bool         `json:"is_done"` // status ditandai is_done pada workflow task
+	Priority     TaskPriority `json:"priority"`
+	StartAt      *time.Time   `json:"start_at"`
+	DueAt        *time.Time   `json:"due_at"`
+	IsOverdue    bool         `json:"is_overdue"`
+	RemindedAt   *time.Time   `json:"-"`
+	DeletedAt    *time.Time   `json:"deleted_at,omitempty"` // terisi jika task ada di trash
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    *time.Time   `json:"updated_at,omitempty"`
+	Version      int          `json:"version"` // naik setiap update, dipakai sebagai ETag
+	Rank         string       `json:"rank"`    // urutan task dalam kolom board
+	Labels       []Label      `json:"labels"`
+
+	// ID task yang harus selesai sebelum task ini, dan task yang menunggu task ini
+	BlockedBy []int `json:"blocked_by"`
+	Blocking  []int `json:"blocking"`
+
+	// RRULE dari series, nil jika task tidak berulang
+	RecurrenceRule *string `json:"recurrence_rule"`
+
+	// Rollup subtasks langsung, Progress nil jika task tidak punya subtask
+	SubtaskCount          int  `json:"subtask_count"`
+	CompletedSubtaskCount int  `json:"completed_subtask_count"`
+	Progress              *int `json:"progress,omitempty"`
+
+	// Description Markdown yang dirender menjadi HTML yang disanitasi,
+	// diisi oleh MarshalJSON
+	DescriptionHTML *string `json:"description_html"`
+
+	// Potongan text yang cocok dengan pencarian, hanya terisi saat ?search=
+	Highlights *TaskHighlights `json:"highlights,omitempty"`
+}
+
+// MarshalJSON merender description menjadi description_html saat task
+// dikirim sebagai JSON, sehingga Markdown hanya dirender untuk response yang
+// memuat task dan tidak untuk export, reminder, atau calendar feed
+func (t Task) MarshalJSON() ([]byte, error) {
+	type task Task
+	if t.Description != nil {
+		descriptionHTML := markdown.Render(*t.Description)
+		t.DescriptionHTML = &descriptionHTML
+	}
+	return json.Marshal(task(t))
+}
+
+// TaskHighlights berisi snippet HTML dengan kecocokan pencarian dibungkus
+// <mark>. Field kosong jika tidak ada kecocokan pada bagian tersebut.
+type TaskHighlights struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Comment     string `json:"comment,omitempty"`
 }
 
+// MaxTaskDepth adalah kedalaman maksimum pohon task (task root = 1)
+const MaxTaskDepth = 3
+
 type TaskStatus string
 
 const (
@@ -20,18 +83,232 @@ const (
 	TaskStatusCompleted  TaskStatus = "completed"
 )
 
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// TaskPriorities berisi semua priority yang valid, urut dari terendah
+var TaskPriorities = []TaskPriority{
+	TaskPriorityLow,
+	TaskPriorityMedium,
+	TaskPriorityHigh,
+	TaskPriorityUrgent,
+}
+
+// IsValid mengecek apakah priority termasuk nilai yang didukung
+func (p TaskPriority) IsValid() bool {
+	for _, priority := range TaskPriorities {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// Nilai parameter sort yang didukung untuk list tasks
+const (
+	TaskSortNewest   = "-created_at"
+	TaskSortOldest   = "created_at"
+	TaskSortPriority = "priority" // urgent dulu, lalu due date terdekat
+	TaskSortDueDate  = "due_at"   // due date terdekat dulu, lalu priority
+
+	// TaskSortRelevance mengurutkan hasil pencarian dari yang paling relevan,
+	// default saat search diisi
+	TaskSortRelevance = "relevance"
+)
+
+// IsValidTaskSort mengecek apakah nilai sort didukung
+func IsValidTaskSort(sort string) bool {
+	switch sort {
+	case TaskSortNewest, TaskSortOldest, TaskSortPriority, TaskSortDueDate, TaskSortRelevance:
+		return true
+	}
+	return false
+}
+
+// Overdue mengecek apakah task sudah lewat due date dan belum selesai
+func (t *Task) Overdue(now time.Time) bool {
+	return t.DueAt != nil && !t.IsDone && t.DueAt.Before(now)
+}
+
+// SubtaskDeleteMode menentukan nasib subtasks saat parent dihapus
+type SubtaskDeleteMode string
+
+const (
+	SubtaskDeleteCascade  SubtaskDeleteMode = "cascade"  // hapus semua subtasks
+	SubtaskDeleteReparent SubtaskDeleteMode = "reparent" // pindahkan subtasks ke parent dari task yang dihapus
+)
+
 // TaskCreateRequest for creating task
 type TaskCreateRequest struct {
-	Title       string     `json:"title" validate:"required,max=255"`
-	Description *string    `json:"description"`
-	Status      TaskStatus `json:"status" validate:"omitempty,oneof=pending in_progress completed"`
+	Title       string       `json:"title" validate:"required,max=255"`
+	Description *string      `json:"description"`
+	Status      TaskStatus   `json:"status" validate:"omitempty,task_status"`
+	Priority    TaskPriority `json:"priority" validate:"omitempty,task_priority"`
+	StartAt     *time.Time   `json:"start_at"`
+	DueAt       *time.Time   `json:"due_at"`
+	ProjectID   *int         `json:"project_id" validate:"omitempty,gt=0"`
+	AssigneeID  *int         `json:"assignee_id" validate:"omitempty,gt=0"`
+	LabelIDs    []int        `json:"label_ids" validate:"omitempty,dive,gt=0"`
+
+	// RRULE RFC 5545 (misalnya "FREQ=WEEKLY;BYDAY=MO"), dihitung dari due_at
+	RecurrenceRule *string `json:"recurrence_rule" validate:"omitempty,max=500"`
 }
 
 // TaskUpdateRequest for updating task
 type TaskUpdateRequest struct {
-	Title       *string     `json:"title,omitempty" validate:"omitempty,max=255"`
-	Description *string     `json:"description,omitempty"`
-	Status      *TaskStatus `json:"status,omitempty" validate:"omitempty,oneof=pending in_progress completed"`
+	Title       *string       `json:"title,omitempty" validate:"omitempty,max=255"`
+	Description *string       `json:"description,omitempty"`
+	Status      *TaskStatus   `json:"status,omitempty" validate:"omitempty,task_status"`
+	Priority    *TaskPriority `json:"priority,omitempty" validate:"omitempty,task_priority"`
+	StartAt     *time.Time    `json:"start_at,omitempty"`
+	DueAt       *time.Time    `json:"due_at,omitempty"`
+	ProjectID   *int          `json:"project_id,omitempty" validate:"omitempty,gte=0"`  // 0 mengeluarkan task dari project
+	AssigneeID  *int          `json:"assignee_id,omitempty" validate:"omitempty,gte=0"` // 0 menghapus assignee
+	LabelIDs    *[]int        `json:"label_ids,omitempty" validate:"omitempty,dive,gt=0"`
+
+	// String kosong menghentikan pengulangan
+	RecurrenceRule *string `json:"recurrence_rule,omitempty" validate:"omitempty,max=500"`
+
+	// IfMatch berisi version dari header If-Match, nil jika tanpa precondition
+	IfMatch []int `json:"-"`
+}
+
+// TaskDependencyRequest for adding a blocker to a task
+type TaskDependencyRequest struct {
+	BlockerID int `json:"blocker_id" validate:"required,gt=0"`
+}
+
+// TaskChecklistRequest for checking or unchecking a task list item in the
+// description
+type TaskChecklistRequest struct {
+	Checked *bool `json:"checked" validate:"required"`
+
+	// IfMatch berisi version dari header If-Match, nil jika tanpa precondition
+	IfMatch []int `json:"-"`
+}
+
+// TaskPatch adalah bagian task yang bisa diubah lewat PATCH. Patch
+// diterapkan ke dokumen ini, hasilnya divalidasi, lalu disimpan apa adanya
+// sehingga field nullable bisa dikosongkan dengan null.
+type TaskPatch struct {
+	Title       string       `json:"title" validate:"required,max=255"`
+	Description *string      `json:"description"`
+	Status      TaskStatus   `json:"status" validate:"required,task_status"`
+	Priority    TaskPriority `json:"priority" validate:"required,task_priority"`
+	StartAt     *time.Time   `json:"start_at"`
+	DueAt       *time.Time   `json:"due_at"`
+	ProjectID   *int         `json:"project_id" validate:"omitempty,gt=0"`
+	AssigneeID  *int         `json:"assignee_id" validate:"omitempty,gt=0"`
+	LabelIDs    []int        `json:"label_ids" validate:"omitempty,dive,gt=0"`
+
+	// Version task yang menjadi dasar patch
+	Version int `json:"-"`
+}
+
+// NewTaskPatch membuat dokumen patch dari state task saat ini
+func NewTaskPatch(task *Task) *TaskPatch {
+	labelIDs := make([]int, len(task.Labels))
+	for i, label := range task.Labels {
+		labelIDs[i] = label.ID
+	}
+
+	return &TaskPatch{
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		StartAt:     task.StartAt,
+		DueAt:       task.DueAt,
+		ProjectID:   task.ProjectID,
+		AssigneeID:  task.AssigneeID,
+		LabelIDs:    labelIDs,
+		Version:     task.Version,
+	}
+}
+
+// MatchesVersion mengecek precondition If-Match; versions nil berarti tanpa
+// precondition
+func (t *Task) MatchesVersion(versions []int) bool {
+	if versions == nil {
+		return true
+	}
+	for _, version := range versions {
+		if version == t.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusOnly mengecek apakah request hanya mengubah status, satu-satunya
+// perubahan yang boleh dilakukan assignee
+func (r *TaskUpdateRequest) StatusOnly() bool {
+	return r.Title == nil && r.Description == nil && r.Priority == nil &&
+		r.StartAt == nil && r.DueAt == nil && r.ProjectID == nil &&
+		r.AssigneeID == nil && r.LabelIDs == nil && r.RecurrenceRule == nil
+}
+
+// TaskFilter berisi filter untuk query list tasks
+type TaskFilter struct {
+	Status    string
+	Priority  string
+	Search    string
+	Labels    []string
+	LabelMode string
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Overdue   bool
+	Sort      string
+
+	// ProjectID membatasi ke satu project. Tanpa ProjectID, tasks dari project
+	// yang diarsipkan disembunyikan kecuali IncludeArchived true.
+	ProjectID       *int
+	IncludeArchived bool
+
+	// AssignedTo membatasi ke tasks yang di-assign ke user tertentu
+	AssignedTo *int
+
+	// Expr adalah ekspresi ?filter= yang sudah divalidasi terhadap
+	// TaskFilterFields, nil jika tidak diisi
+	Expr filterql.Node
+
+	// SortFields berisi daftar sort kustom jika Sort bukan salah satu
+	// nilai TaskSort*
+	SortFields []filterql.SortField
+}
+
+// TaskFilterFields adalah field yang boleh dipakai pada ekspresi ?filter=
+var TaskFilterFields = filterql.Fields{
+	"title":          {Type: filterql.String},
+	"description":    {Type: filterql.String, Nullable: true},
+	"status":         {Type: filterql.String},
+	"priority":       {Type: filterql.Enum, Values: []string{"low", "medium", "high", "urgent"}},
+	"user_id":        {Type: filterql.Int},
+	"project_id":     {Type: filterql.Int, Nullable: true},
+	"assignee_id":    {Type: filterql.Int, Nullable: true},
+	"parent_task_id": {Type: filterql.Int, Nullable: true},
+	"start_at":       {Type: filterql.Time, Nullable: true},
+	"due_at":         {Type: filterql.Time, Nullable: true},
+	"created_at":     {Type: filterql.Time},
+	"updated_at":     {Type: filterql.Time, Nullable: true},
+}
+
+// TaskSortFields adalah field yang boleh dipakai pada daftar ?sort= kustom
+var TaskSortFields = map[string]bool{
+	"id":         true,
+	"title":      true,
+	"status":     true,
+	"priority":   true,
+	"start_at":   true,
+	"due_at":     true,
+	"created_at": true,
+	"updated_at": true,
 }
 
 // Response DTOs
@@ -39,7 +316,11 @@ type TaskUpdateRequest struct {
 // TasksResponse for paginated tasks response
 type TasksResponse struct {
 	Tasks []Task `json:"tasks"`
-	Total int    `json:"total"`
-	Page  int    `json:"page"`
+	Total *int   `json:"total,omitempty"` // dengan cursor hanya dihitung jika include_total=true
+	Page  int    `json:"page,omitempty"`  // kosong pada cursor pagination
 	Limit int    `json:"limit"`
+
+	// Cursor untuk halaman berikutnya dan sebelumnya, kosong jika tidak ada
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
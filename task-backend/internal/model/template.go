@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// TaskTemplate adalah kerangka task milik user yang dapat dipakai berulang
+// kali. Title dan description (juga pada subtask) boleh berisi placeholder
+// seperti {{date}} dan {{name}} yang diisi saat template dipakai.
+type TaskTemplate struct {
+	ID          int               `json:"id"`
+	UserID      int               `json:"user_id"`
+	Name        string            `json:"name"`
+	Title       string            `json:"title"`
+	Description *string           `json:"description"`
+	Status      TaskStatus        `json:"status,omitempty"`   // kosong berarti status awal workflow
+	Priority    TaskPriority      `json:"priority,omitempty"` // kosong berarti priority default
+	LabelIDs    []int             `json:"label_ids"`
+	Subtasks    []TemplateSubtask `json:"subtasks"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   *time.Time        `json:"updated_at,omitempty"`
+}
+
+// TemplateSubtask adalah subtask yang ikut dibuat saat template dipakai
+type TemplateSubtask struct {
+	Title       string  `json:"title" validate:"required,max=255"`
+	Description *string `json:"description"`
+}
+
+// TaskTemplateRequest for creating and replacing a template, maksimal 50 subtask
+type TaskTemplateRequest struct {
+	Name        string            `json:"name" validate:"required,max=100"`
+	Title       string            `json:"title" validate:"required,max=255"`
+	Description *string           `json:"description"`
+	Status      TaskStatus        `json:"status" validate:"omitempty,task_status"`
+	Priority    TaskPriority      `json:"priority" validate:"omitempty,task_priority"`
+	LabelIDs    []int             `json:"label_ids" validate:"omitempty,dive,gt=0"`
+	Subtasks    []TemplateSubtask `json:"subtasks" validate:"omitempty,max=50,dive"`
+}
+
+// TemplateInstantiateRequest for creating tasks from a template. Variables
+// mengisi placeholder dan boleh menimpa placeholder bawaan.
+type TemplateInstantiateRequest struct {
+	Variables map[string]string `json:"variables" validate:"omitempty,dive,max=255"`
+	ProjectID *int              `json:"project_id" validate:"omitempty,gt=0"`
+}
+
+// TemplateInstantiateResponse berisi task yang dibuat dari template
+type TemplateInstantiateResponse struct {
+	Task     Task   `json:"task"`
+	Subtasks []Task `json:"subtasks"`
+}
@@ -0,0 +1,79 @@
+package model
+
+import "time"
+
+// Format file import/export tasks
+const (
+	TransferFormatCSV    = "csv"
+	TransferFormatJSON   = "json"
+	TransferFormatNDJSON = "ndjson"
+)
+
+// MaxImportRows membatasi jumlah baris dalam satu import
+const MaxImportRows = 5000
+
+// TaskRecord adalah bentuk satu task pada file import/export. ID dan
+// ParentTaskID pada file import hanya dipakai untuk menghubungkan subtask
+// dengan parent-nya di file yang sama; task selalu dibuat dengan ID baru.
+type TaskRecord struct {
+	ID           int          `json:"id,omitempty"`
+	ParentTaskID *int         `json:"parent_task_id"`
+	ProjectID    *int         `json:"project_id" validate:"omitempty,gt=0"`
+	AssigneeID   *int         `json:"assignee_id" validate:"omitempty,gt=0"`
+	Title        string       `json:"title" validate:"required,max=255"`
+	Description  *string      `json:"description"`
+	Status       TaskStatus   `json:"status" validate:"omitempty,task_status"`
+	Priority     TaskPriority `json:"priority" validate:"omitempty,task_priority"`
+	StartAt      *time.Time   `json:"start_at"`
+	DueAt        *time.Time   `json:"due_at"`
+	LabelIDs     []int        `json:"label_ids" validate:"omitempty,dive,gt=0"`
+	CreatedAt    *time.Time   `json:"created_at,omitempty"` // diabaikan saat import
+}
+
+// NewTaskRecord membuat TaskRecord dari task untuk export
+func NewTaskRecord(task *Task) TaskRecord {
+	record := TaskRecord{
+		ID:           task.ID,
+		ParentTaskID: task.ParentTaskID,
+		ProjectID:    task.ProjectID,
+		AssigneeID:   task.AssigneeID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		StartAt:      task.StartAt,
+		DueAt:        task.DueAt,
+		LabelIDs:     []int{},
+		CreatedAt:    &task.CreatedAt,
+	}
+	for _, label := range task.Labels {
+		record.LabelIDs = append(record.LabelIDs, label.ID)
+	}
+	return record
+}
+
+// TaskImportRow adalah satu baris file import. Errors berisi error saat
+// membaca baris, misalnya tanggal dengan format salah.
+type TaskImportRow struct {
+	Row    int
+	Record TaskRecord
+	Errors []ValidationError
+}
+
+// TaskImportError berisi semua error pada satu baris import. Row dihitung
+// dari 1 tanpa header CSV.
+type TaskImportError struct {
+	Row    int               `json:"row"`
+	Errors []ValidationError `json:"errors"`
+}
+
+// TaskImportResponse adalah hasil import. Jika ada satu baris yang gagal
+// atau DryRun true, tidak ada task yang disimpan dan Created bernilai 0.
+type TaskImportResponse struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Created int               `json:"created"`
+	TaskIDs []int             `json:"task_ids,omitempty"`
+	Errors  []TaskImportError `json:"errors"`
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultWorkflowStatuses adalah status pada workflow default, sama dengan
+// status task sebelum workflow dapat dikonfigurasi
+var DefaultWorkflowStatuses = []TaskStatus{TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted}
+
+// Workflow mendefinisikan status yang boleh dipakai task beserta transisi
+// yang diizinkan di antaranya. Task mengikuti workflow project-nya, atau
+// workflow default jika task tidak berada di project yang punya workflow.
+type Workflow struct {
+	ID          int                  `json:"id"`
+	Name        string               `json:"name"`
+	IsDefault   bool                 `json:"is_default"`
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   *time.Time           `json:"updated_at,omitempty"`
+}
+
+// NewDefaultWorkflow membuat workflow default bawaan, dipakai jika workflow
+// default belum ada di database
+func NewDefaultWorkflow() *Workflow {
+	workflow := &Workflow{Name: "Default", IsDefault: true}
+	for i, status := range DefaultWorkflowStatuses {
+		workflow.Statuses = append(workflow.Statuses, WorkflowStatus{Key: status, Name: string(status), IsInitial: i == 0, IsDone: status == TaskStatusCompleted})
+		for _, to := range DefaultWorkflowStatuses {
+			if to != status {
+				workflow.Transitions = append(workflow.Transitions, WorkflowTransition{From: status, To: to})
+			}
+		}
+	}
+	return workflow
+}
+
+// WorkflowStatus adalah satu status dalam workflow. Task baru memakai status
+// dengan IsInitial jika tidak menentukan status sendiri. Task dengan status
+// IsDone dianggap selesai, misalnya tidak lagi overdue atau memblokir task lain.
+type WorkflowStatus struct {
+	Key       TaskStatus `json:"key" validate:"required,max=30,status_key"`
+	Name      string     `json:"name" validate:"required,max=50"`
+	IsInitial bool       `json:"is_initial"`
+	IsDone    bool       `json:"is_done"`
+}
+
+// WorkflowTransition mengizinkan perpindahan status dari From ke To
+type WorkflowTransition struct {
+	From TaskStatus `json:"from" validate:"required"`
+	To   TaskStatus `json:"to" validate:"required"`
+}
+
+// HasStatus mengecek apakah status terdapat pada workflow
+func (w *Workflow) HasStatus(status TaskStatus) bool {
+	for _, s := range w.Statuses {
+		if s.Key == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDone mengecek apakah status termasuk status selesai pada workflow
+func (w *Workflow) IsDone(status TaskStatus) bool {
+	for _, s := range w.Statuses {
+		if s.Key == status {
+			return s.IsDone
+		}
+	}
+	return false
+}
+
+// InitialStatus mengembalikan status awal untuk task baru
+func (w *Workflow) InitialStatus() TaskStatus {
+	for _, s := range w.Statuses {
+		if s.IsInitial {
+			return s.Key
+		}
+	}
+	return TaskStatusPending
+}
+
+// CanTransition mengecek apakah perpindahan status from -> to diizinkan.
+// Status yang tidak ada pada workflow (misalnya setelah project berganti
+// workflow) boleh berpindah ke status mana pun agar task tidak terkunci.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to || !w.HasStatus(from) {
+		return true
+	}
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowRequest for creating or replacing workflow
+type WorkflowRequest struct {
+	Name        string               `json:"name" validate:"required,max=100"`
+	Statuses    []WorkflowStatus     `json:"statuses" validate:"required,min=1,dive"`
+	Transitions []WorkflowTransition `json:"transitions" validate:"dive"`
+}
+
+// knownStatuses adalah gabungan status dari semua workflow, dipakai oleh
+// validator untuk menolak status yang tidak dikenal sebelum masuk service
+var knownStatuses = struct {
+	sync.RWMutex
+	set map[TaskStatus]bool
+}{set: statusSet(DefaultWorkflowStatuses)}
+
+// SetKnownTaskStatuses mengganti daftar status yang dikenal
+func SetKnownTaskStatuses(statuses []TaskStatus) {
+	set := statusSet(statuses)
+
+	knownStatuses.Lock()
+	knownStatuses.set = set
+	knownStatuses.Unlock()
+}
+
+// IsKnown mengecek apakah status dipakai oleh salah satu workflow
+func (s TaskStatus) IsKnown() bool {
+	knownStatuses.RLock()
+	defer knownStatuses.RUnlock()
+	return knownStatuses.set[s]
+}
+
+func statusSet(statuses []TaskStatus) map[TaskStatus]bool {
+	set := make(map[TaskStatus]bool, len(statuses))
+	for _, status := range statuses {
+		set[status] = true
+	}
+	return set
+}
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// Worklog adalah catatan waktu kerja user pada task. EndedAt nil berarti
+// timer masih berjalan; setiap user hanya boleh punya satu timer berjalan.
+type Worklog struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	UserID    int        `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      *string    `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Durasi dalam detik, untuk timer yang berjalan dihitung sampai sekarang
+	DurationSeconds int `json:"duration_seconds"`
+}
+
+// Running mengecek apakah worklog adalah timer yang masih berjalan
+func (w *Worklog) Running() bool {
+	return w.EndedAt == nil
+}
+
+// TimerStartRequest for starting a timer on a task
+type TimerStartRequest struct {
+	Note *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// WorklogCreateRequest for adding a manual worklog entry
+type WorklogCreateRequest struct {
+	StartedAt time.Time `json:"started_at" validate:"required"`
+	EndedAt   time.Time `json:"ended_at" validate:"required"`
+	Note      *string   `json:"note" validate:"omitempty,max=1000"`
+}
+
+// WorklogRange membatasi worklog berdasarkan started_at, From inklusif dan
+// To eksklusif. Nil berarti tidak dibatasi.
+type WorklogRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// UserTime adalah total waktu satu user
+type UserTime struct {
+	UserID  int `json:"user_id"`
+	Seconds int `json:"seconds"`
+}
+
+// TaskTime adalah total waktu pada satu task
+type TaskTime struct {
+	TaskID  int    `json:"task_id"`
+	Title   string `json:"title"`
+	Seconds int    `json:"seconds"`
+}
+
+// TaskWorklogsResponse for task worklogs with totals per user
+type TaskWorklogsResponse struct {
+	Worklogs     []Worklog  `json:"worklogs"`
+	TotalSeconds int        `json:"total_seconds"`
+	Users        []UserTime `json:"users"`
+}
+
+// WorklogSummary for a user's total time per task over a date range
+type WorklogSummary struct {
+	UserID       int        `json:"user_id"`
+	From         *time.Time `json:"from"`
+	To           *time.Time `json:"to"`
+	TotalSeconds int        `json:"total_seconds"`
+	Tasks        []TaskTime `json:"tasks"`
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type ActivityRepository interface {
+	Create(activities []model.TaskActivity) error
+	GetByTaskID(taskID int, page, limit int) ([]model.TaskActivity, int, error)
+}
+
+type activityRepository struct {
+	db DBTX
+}
+
+// NewActivityRepository membuat instance ActivityRepository
+func NewActivityRepository(db DBTX) ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+// Create menyimpan beberapa entri activity dengan satu query
+func (r *activityRepository) Create(activities []model.TaskActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(activities))
+	args := make([]interface{}, 0, len(activities)*6)
+	for i, activity := range activities {
+		values[i] = "(?, ?, ?, ?, ?, ?)"
+		args = append(args, activity.TaskID, activity.UserID, activity.Action, activity.Field, activity.OldValue, activity.NewValue)
+	}
+
+	query := "INSERT INTO task_activities (task_id, user_id, action, field, old_value, new_value) VALUES " +
+		strings.Join(values, ", ")
+
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to create task activities: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTaskID mengambil riwayat task dengan pagination, terbaru lebih dulu
+func (r *activityRepository) GetByTaskID(taskID int, page, limit int) ([]model.TaskActivity, int, error) {
+	offset := (page - 1) * limit
+
+	query := `
+		SELECT id, task_id, user_id, action, field, old_value, new_value, created_at
+		FROM task_activities
+		WHERE task_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.Query(query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get task activities: %w", err)
+	}
+	defer rows.Close()
+
+	var activities []model.TaskActivity
+	for rows.Next() {
+		var activity model.TaskActivity
+		err := rows.Scan(
+			&activity.ID,
+			&activity.TaskID,
+			&activity.UserID,
+			&activity.Action,
+			&activity.Field,
+			&activity.OldValue,
+			&activity.NewValue,
+			&activity.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan task activity: %w", err)
+		}
+		activities = append(activities, activity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate task activities: %w", err)
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM task_activities WHERE task_id = ?"
+	if err := r.db.QueryRow(countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count task activities: %w", err)
+	}
+
+	return activities, total, nil
+}
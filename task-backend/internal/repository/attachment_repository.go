@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *model.Attachment) error
+	GetByID(id int) (*model.Attachment, error)
+	GetByTaskID(taskID int) ([]model.Attachment, error)
+	GetByTaskIDs(taskIDs []int) ([]model.Attachment, error)
+	Delete(id int) error
+}
+
+// attachmentColumns adalah kolom yang dipilih untuk setiap query attachment
+const attachmentColumns = "a.id, a.task_id, a.user_id, a.file_name, a.content_type, a.size, a.storage_key, a.created_at"
+
+type attachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository membuat instance AttachmentRepository
+func NewAttachmentRepository(db *sql.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// scanAttachment membaca satu baris attachment sesuai urutan attachmentColumns
+func scanAttachment(row rowScanner) (*model.Attachment, error) {
+	var attachment model.Attachment
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UserID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// Create menyimpan metadata attachment baru
+func (r *attachmentRepository) Create(attachment *model.Attachment) error {
+	query := `
+		INSERT INTO task_attachments (task_id, user_id, file_name, content_type, size, storage_key)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, attachment.TaskID, attachment.UserID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	attachment.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil attachment berdasarkan ID
+func (r *attachmentRepository) GetByID(id int) (*model.Attachment, error) {
+	query := fmt.Sprintf("SELECT %s FROM task_attachments a WHERE a.id = ?", attachmentColumns)
+
+	attachment, err := scanAttachment(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get attachment by id: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetByTaskID mengambil semua attachment pada task
+func (r *attachmentRepository) GetByTaskID(taskID int) ([]model.Attachment, error) {
+	return r.GetByTaskIDs([]int{taskID})
+}
+
+// GetByTaskIDs mengambil semua attachment pada beberapa task sekaligus
+func (r *attachmentRepository) GetByTaskIDs(taskIDs []int) ([]model.Attachment, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM task_attachments a
+		WHERE a.task_id IN (%s)
+		ORDER BY a.created_at ASC, a.id ASC
+	`, attachmentColumns, placeholders(len(taskIDs)))
+
+	rows, err := r.db.Query(query, intArgs(taskIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []model.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Delete menghapus metadata attachment
+func (r *attachmentRepository) Delete(id int) error {
+	query := "DELETE FROM task_attachments WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type CalendarTokenRepository interface {
+	Save(token *model.CalendarToken) error
+	GetByUserID(userID int) (*model.CalendarToken, error)
+	GetByHash(tokenHash string) (*model.CalendarToken, error)
+	Delete(userID int) error
+}
+
+type calendarTokenRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarTokenRepository membuat instance CalendarTokenRepository
+func NewCalendarTokenRepository(db *sql.DB) CalendarTokenRepository {
+	return &calendarTokenRepository{db: db}
+}
+
+// Save menyimpan token user, token lama langsung tidak berlaku
+func (r *calendarTokenRepository) Save(token *model.CalendarToken) error {
+	query := `
+		INSERT INTO calendar_tokens (user_id, token_hash) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.Exec(query, token.UserID, token.TokenHash); err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID mengambil token milik user
+func (r *calendarTokenRepository) GetByUserID(userID int) (*model.CalendarToken, error) {
+	return r.getOne("user_id = ?", userID)
+}
+
+// GetByHash mengambil token berdasarkan hash token pada URL feed
+func (r *calendarTokenRepository) GetByHash(tokenHash string) (*model.CalendarToken, error) {
+	return r.getOne("token_hash = ?", tokenHash)
+}
+
+func (r *calendarTokenRepository) getOne(condition string, args ...interface{}) (*model.CalendarToken, error) {
+	query := fmt.Sprintf("SELECT user_id, token_hash, created_at FROM calendar_tokens WHERE %s", condition)
+
+	var token model.CalendarToken
+	err := r.db.QueryRow(query, args...).Scan(&token.UserID, &token.TokenHash, &token.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Delete mencabut token user
+func (r *calendarTokenRepository) Delete(userID int) error {
+	if _, err := r.db.Exec("DELETE FROM calendar_tokens WHERE user_id = ?", userID); err != nil {
+		return fmt.Errorf("failed to delete calendar token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type CommentRepository interface {
+	Create(comment *model.Comment) error
+	GetByID(id int) (*model.Comment, error)
+	GetByTaskID(taskID int, page, limit int) ([]model.Comment, int, error)
+	Update(comment *model.Comment) error
+	Delete(id int) error
+}
+
+// commentColumns adalah kolom yang dipilih untuk setiap query comment
+const commentColumns = "c.id, c.task_id, c.user_id, c.parent_id, c.body, c.created_at, c.updated_at"
+
+type commentRepository struct {
+	db *sql.DB
+}
+
+// NewCommentRepository membuat instance CommentRepository
+func NewCommentRepository(db *sql.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// scanComment membaca satu baris comment sesuai urutan commentColumns
+func scanComment(row rowScanner) (*model.Comment, error) {
+	var comment model.Comment
+	err := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.UserID,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// scanComments membaca semua baris hasil query comment
+func scanComments(rows *sql.Rows) ([]model.Comment, error) {
+	var comments []model.Comment
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Create membuat comment baru
+func (r *commentRepository) Create(comment *model.Comment) error {
+	query := "INSERT INTO task_comments (task_id, user_id, parent_id, body) VALUES (?, ?, ?, ?)"
+
+	result, err := r.db.Exec(query, comment.TaskID, comment.UserID, comment.ParentID, comment.Body)
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	comment.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil comment berdasarkan ID
+func (r *commentRepository) GetByID(id int) (*model.Comment, error) {
+	query := fmt.Sprintf("SELECT %s FROM task_comments c WHERE c.id = ?", commentColumns)
+
+	comment, err := scanComment(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get comment by id: %w", err)
+	}
+
+	return comment, nil
+}
+
+// GetByTaskID mengambil komentar root pada task dengan pagination, beserta
+// semua reply-nya
+func (r *commentRepository) GetByTaskID(taskID int, page, limit int) ([]model.Comment, int, error) {
+	offset := (page - 1) * limit
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM task_comments c
+		WHERE c.task_id = ? AND c.parent_id IS NULL
+		ORDER BY c.created_at ASC, c.id ASC
+		LIMIT ? OFFSET ?
+	`, commentColumns)
+
+	rows, err := r.db.Query(query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments, err := scanComments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.attachReplies(comments); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM task_comments WHERE task_id = ? AND parent_id IS NULL"
+	if err := r.db.QueryRow(countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+// attachReplies mengisi Replies untuk setiap komentar root dengan satu query
+func (r *commentRepository) attachReplies(comments []model.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	parentIDs := make([]int, len(comments))
+	for i, comment := range comments {
+		parentIDs[i] = comment.ID
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM task_comments c
+		WHERE c.parent_id IN (%s)
+		ORDER BY c.created_at ASC, c.id ASC
+	`, commentColumns, placeholders(len(parentIDs)))
+
+	rows, err := r.db.Query(query, intArgs(parentIDs)...)
+	if err != nil {
+		return fmt.Errorf("failed to get comment replies: %w", err)
+	}
+	defer rows.Close()
+
+	replies, err := scanComments(rows)
+	if err != nil {
+		return err
+	}
+
+	repliesByParent := make(map[int][]model.Comment)
+	for _, reply := range replies {
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], reply)
+	}
+	for i := range comments {
+		comments[i].Replies = repliesByParent[comments[i].ID]
+	}
+
+	return nil
+}
+
+// Update mengupdate isi comment
+func (r *commentRepository) Update(comment *model.Comment) error {
+	query := "UPDATE task_comments SET body = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+
+	_, err := r.db.Exec(query, comment.Body, comment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return nil
+}
+
+// Delete menghapus comment, reply ikut terhapus lewat foreign key
+func (r *commentRepository) Delete(id int) error {
+	query := "DELETE FROM task_comments WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type LabelRepository interface {
+	Create(label *model.Label) error
+	GetByID(id int) (*model.Label, error)
+	GetByIDs(ids []int) ([]model.Label, error)
+	GetByName(userID int, name string) (*model.Label, error)
+	GetByUserID(userID int) ([]model.Label, error)
+	Update(label *model.Label) error
+	Delete(id int) error
+}
+
+// labelColumns adalah kolom yang dipilih untuk setiap query label
+const labelColumns = "l.id, l.user_id, l.name, l.color, l.created_at, l.updated_at"
+
+type labelRepository struct {
+	db *sql.DB
+}
+
+// NewLabelRepository membuat instance LabelRepository
+func NewLabelRepository(db *sql.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+// scanLabel membaca satu baris label sesuai urutan labelColumns
+func scanLabel(row rowScanner) (*model.Label, error) {
+	var label model.Label
+	err := row.Scan(
+		&label.ID,
+		&label.UserID,
+		&label.Name,
+		&label.Color,
+		&label.CreatedAt,
+		&label.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+// scanLabels membaca semua baris hasil query label
+func scanLabels(rows *sql.Rows) ([]model.Label, error) {
+	var labels []model.Label
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, *label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// Create membuat label baru
+func (r *labelRepository) Create(label *model.Label) error {
+	query := "INSERT INTO labels (user_id, name, color) VALUES (?, ?, ?)"
+
+	result, err := r.db.Exec(query, label.UserID, label.Name, label.Color)
+	if err != nil {
+		return fmt.Errorf("failed to create label: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	label.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil label berdasarkan ID
+func (r *labelRepository) GetByID(id int) (*model.Label, error) {
+	query := fmt.Sprintf("SELECT %s FROM labels l WHERE l.id = ?", labelColumns)
+
+	label, err := scanLabel(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get label by id: %w", err)
+	}
+
+	return label, nil
+}
+
+// GetByIDs mengambil labels berdasarkan daftar ID
+func (r *labelRepository) GetByIDs(ids []int) ([]model.Label, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM labels l WHERE l.id IN (%s)", labelColumns, placeholders(len(ids)))
+
+	rows, err := r.db.Query(query, intArgs(ids)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels by ids: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLabels(rows)
+}
+
+// GetByName mengambil label milik user berdasarkan nama
+func (r *labelRepository) GetByName(userID int, name string) (*model.Label, error) {
+	query := fmt.Sprintf("SELECT %s FROM labels l WHERE l.user_id = ? AND l.name = ?", labelColumns)
+
+	label, err := scanLabel(r.db.QueryRow(query, userID, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get label by name: %w", err)
+	}
+
+	return label, nil
+}
+
+// GetByUserID mengambil semua labels milik user
+func (r *labelRepository) GetByUserID(userID int) ([]model.Label, error) {
+	query := fmt.Sprintf("SELECT %s FROM labels l WHERE l.user_id = ? ORDER BY l.name ASC", labelColumns)
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLabels(rows)
+}
+
+// Update mengupdate label
+func (r *labelRepository) Update(label *model.Label) error {
+	query := `
+		UPDATE labels
+		SET name = ?, color = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+
+	_, err := r.db.Exec(query, label.Name, label.Color, label.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return nil
+}
+
+// Delete menghapus label, relasi task_labels ikut terhapus lewat foreign key
+func (r *labelRepository) Delete(id int) error {
+	query := "DELETE FROM labels WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
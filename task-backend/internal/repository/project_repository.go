@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type ProjectRepository interface {
+	Create(project *model.Project) error
+	GetByID(id int) (*model.Project, error)
+	GetByUserID(userID int, includeArchived bool) ([]model.Project, error)
+	Update(project *model.Project) error
+	Delete(id int) error
+}
+
+// projectColumns adalah kolom yang dipilih untuk setiap query project
+const projectColumns = "p.id, p.user_id, p.name, p.description, p.workflow_id, p.archived, p.created_at, p.updated_at"
+
+type projectRepository struct {
+	db *sql.DB
+}
+
+// NewProjectRepository membuat instance ProjectRepository
+func NewProjectRepository(db *sql.DB) ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+// scanProject membaca satu baris project sesuai urutan projectColumns
+func scanProject(row rowScanner) (*model.Project, error) {
+	var project model.Project
+	var description sql.NullString
+
+	err := row.Scan(
+		&project.ID,
+		&project.UserID,
+		&project.Name,
+		&description,
+		&project.WorkflowID,
+		&project.Archived,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		project.Description = &description.String
+	}
+
+	return &project, nil
+}
+
+// Create membuat project baru
+func (r *projectRepository) Create(project *model.Project) error {
+	query := "INSERT INTO projects (user_id, name, description, workflow_id, archived) VALUES (?, ?, ?, ?, ?)"
+
+	result, err := r.db.Exec(query, project.UserID, project.Name, project.Description, project.WorkflowID, project.Archived)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	project.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil project berdasarkan ID
+func (r *projectRepository) GetByID(id int) (*model.Project, error) {
+	query := fmt.Sprintf("SELECT %s FROM projects p WHERE p.id = ?", projectColumns)
+
+	project, err := scanProject(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get project by id: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetByUserID mengambil projects milik user, project yang diarsipkan
+// hanya disertakan jika includeArchived true
+func (r *projectRepository) GetByUserID(userID int, includeArchived bool) ([]model.Project, error) {
+	query := fmt.Sprintf("SELECT %s FROM projects p WHERE p.user_id = ?", projectColumns)
+	if !includeArchived {
+		query += " AND p.archived = FALSE"
+	}
+	query += " ORDER BY p.name ASC"
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []model.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, *project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Update mengupdate project
+func (r *projectRepository) Update(project *model.Project) error {
+	query := `
+		UPDATE projects
+		SET name = ?, description = ?, workflow_id = ?, archived = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+
+	_, err := r.db.Exec(query, project.Name, project.Description, project.WorkflowID, project.Archived, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// Delete menghapus project, project_id pada tasks di-set NULL lewat foreign key
+func (r *projectRepository) Delete(id int) error {
+	query := "DELETE FROM projects WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
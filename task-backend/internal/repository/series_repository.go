@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type SeriesRepository interface {
+	Create(series *model.TaskSeries) error
+	GetByID(id int) (*model.TaskSeries, error)
+	Update(series *model.TaskSeries) error
+}
+
+type seriesRepository struct {
+	db DBTX
+}
+
+// NewSeriesRepository membuat instance SeriesRepository
+func NewSeriesRepository(db DBTX) SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+// Create membuat series task berulang baru
+func (r *seriesRepository) Create(series *model.TaskSeries) error {
+	query := "INSERT INTO task_series (user_id, rrule, start_at) VALUES (?, ?, ?)"
+
+	result, err := r.db.Exec(query, series.UserID, series.Rule, series.StartAt)
+	if err != nil {
+		return fmt.Errorf("failed to create task series: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	series.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil series berdasarkan ID
+func (r *seriesRepository) GetByID(id int) (*model.TaskSeries, error) {
+	query := "SELECT id, user_id, rrule, start_at, created_at, updated_at FROM task_series WHERE id = ?"
+
+	var series model.TaskSeries
+	err := r.db.QueryRow(query, id).Scan(
+		&series.ID,
+		&series.UserID,
+		&series.Rule,
+		&series.StartAt,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get task series by id: %w", err)
+	}
+
+	return &series, nil
+}
+
+// Update mengupdate RRULE series
+func (r *seriesRepository) Update(series *model.TaskSeries) error {
+	query := "UPDATE task_series SET rrule = ?, start_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+
+	_, err := r.db.Exec(query, series.Rule, series.StartAt, series.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update task series: %w", err)
+	}
+
+	return nil
+}
@@ -2,39 +2,194 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/filterql"
+	"github.com/Mahathirrr/task-management-backend/pkg/rank"
+	"github.com/Mahathirrr/task-management-backend/pkg/search"
 )
 
+// ErrVersionConflict dikembalikan Update jika task sudah diubah sejak dibaca
+var ErrVersionConflict = errors.New("task version conflict")
+
+// ErrRankConflict dikembalikan Move jika posisi tetangga sudah berubah
+var ErrRankConflict = errors.New("task rank conflict")
+
 type TaskRepository interface {
 	Create(task *model.Task) error
 	GetByID(id int) (*model.Task, error)
-	GetByUserID(userID int, page, limit int, status, search string) ([]model.Task, int, error)
-	GetAll(page, limit int, status, search string) ([]model.Task, int, error)
+	GetByUserID(userID int, page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error)
+	GetAll(page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error)
 	Update(task *model.Task) error
-	Delete(id int) error
+	SoftDelete(ids []int, deletedAt time.Time) error
+	Restore(id int, deletedAt time.Time) ([]int, error)
+	GetDeletedByID(id int) (*model.Task, error)
+	GetTrash(userID *int, page, limit int) ([]model.Task, int, error)
+	GetTrashedBefore(deletedBefore time.Time) ([]int, error)
+	Purge(ids []int) error
+	ApplyBulk(change *model.TaskBulkChange) error
+	LockTasks(ids []int) error
 	IsOwner(taskID, userID int) (bool, error)
+	SetLabels(taskID int, labelIDs []int) error
+	GetSubtasks(parentID int) ([]model.Task, error)
+	ReparentSubtasks(parentID int, newParentID *int) error
+	GetDueForReminder(dueAfter, dueBefore time.Time) ([]model.Task, error)
+	MarkReminded(taskID int, remindedAt time.Time) error
+	GetSeriesOccurrences(seriesID int, after time.Time) ([]model.Task, error)
+	AddDependency(taskID, blockerID int) error
+	RemoveDependency(taskID, blockerID int) error
+	GetBlockers(taskIDs []int) (map[int][]int, error)
+	GetIncompleteBlockers(taskID int) ([]int, error)
+	Move(move *model.TaskMove) error
+	GetBoardColumn(userID *int, status model.TaskStatus, filter model.TaskFilter, limit int) ([]model.Task, bool, error)
+	GetBoardStatuses(userID *int, filter model.TaskFilter) ([]model.TaskStatus, error)
+}
+
+// taskSortClauses memetakan nilai sort ke klausa ORDER BY. Kolom priority
+// adalah ENUM sehingga urutannya mengikuti urutan definisi (low -> urgent).
+var taskSortClauses = map[string]string{
+	model.TaskSortNewest:   "t.created_at DESC, t.id DESC",
+	model.TaskSortOldest:   "t.created_at ASC, t.id ASC",
+	model.TaskSortPriority: "t.priority DESC, t.due_at IS NULL, t.due_at ASC, t.created_at DESC",
+	model.TaskSortDueDate:  "t.due_at IS NULL, t.due_at ASC, t.priority DESC, t.created_at DESC",
+}
+
+// taskFilterColumns memetakan field ?filter= dan ?sort= ke kolom tasks
+var taskFilterColumns = filterql.Columns{
+	"id":             "t.id",
+	"title":          "t.title",
+	"description":    "t.description",
+	"status":         "t.status",
+	"priority":       "t.priority",
+	"user_id":        "t.user_id",
+	"project_id":     "t.project_id",
+	"assignee_id":    "t.assignee_id",
+	"parent_task_id": "t.parent_task_id",
+	"start_at":       "t.start_at",
+	"due_at":         "t.due_at",
+	"created_at":     "t.created_at",
+	"updated_at":     "t.updated_at",
+}
+
+// taskDoneCondition mengembalikan kondisi SQL yang bernilai true jika status
+// task dengan alias tersebut ditandai is_done pada workflow yang berlaku,
+// yaitu workflow project atau workflow default
+func taskDoneCondition(alias string) string {
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM workflow_statuses ws
+			WHERE ws.status = %[1]s.status AND ws.is_done = TRUE AND ws.workflow_id = COALESCE(
+				(SELECT p.workflow_id FROM projects p WHERE p.id = %[1]s.project_id),
+				(SELECT w.id FROM workflows w WHERE w.is_default = TRUE ORDER BY w.id LIMIT 1)))`, alias)
 }
 
+// taskColumns adalah kolom yang dipilih untuk setiap query task
+var taskColumns = `t.id, t.user_id, t.parent_task_id, t.project_id, t.assignee_id, t.series_id, t.occurrence_at,
+		t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.reminded_at, t.deleted_at, t.created_at, t.updated_at, t.version, t.board_rank,
+		(SELECT ts.rrule FROM task_series ts WHERE ts.id = t.series_id) AS recurrence_rule,
+		(SELECT COUNT(*) FROM tasks st WHERE st.parent_task_id = t.id AND st.deleted_at IS NULL) AS subtask_count,
+		(SELECT COUNT(*) FROM tasks st WHERE st.parent_task_id = t.id AND st.deleted_at IS NULL AND ` + taskDoneCondition("st") + `) AS completed_subtask_count,
+		` + taskDoneCondition("t") + ` AS is_done`
+
 type taskRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewTaskRepository membuat instance TaskRepository
-func NewTaskRepository(db *sql.DB) TaskRepository {
+func NewTaskRepository(db DBTX) TaskRepository {
 	return &taskRepository{db: db}
 }
 
-// Create membuat task baru
+// scanTask membaca satu baris task sesuai urutan taskColumns
+func scanTask(row rowScanner) (*model.Task, error) {
+	var task model.Task
+	var description sql.NullString
+
+	err := row.Scan(
+		&task.ID,
+		&task.UserID,
+		&task.ParentTaskID,
+		&task.ProjectID,
+		&task.AssigneeID,
+		&task.SeriesID,
+		&task.OccurrenceAt,
+		&task.Title,
+		&description,
+		&task.Status,
+		&task.Priority,
+		&task.StartAt,
+		&task.DueAt,
+		&task.RemindedAt,
+		&task.DeletedAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.Version,
+		&task.Rank,
+		&task.RecurrenceRule,
+		&task.SubtaskCount,
+		&task.CompletedSubtaskCount,
+		&task.IsDone,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		task.Description = &description.String
+	}
+	task.IsOverdue = task.Overdue(time.Now())
+
+	if task.SubtaskCount > 0 {
+		progress := task.CompletedSubtaskCount * 100 / task.SubtaskCount
+		task.Progress = &progress
+	}
+
+	return &task, nil
+}
+
+// scanTasks membaca semua baris hasil query task
+func scanTasks(rows *sql.Rows) ([]model.Task, error) {
+	var tasks []model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Create membuat task baru di akhir board. Rank unik per pemilik, sehingga
+// hanya rank terakhir milik user yang sama yang dikunci.
 func (r *taskRepository) Create(task *model.Task) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	last, err := ownerAdjacentRank(tx, task.UserID, "", false, 0)
+	if err != nil {
+		return err
+	}
+	if task.Rank, err = rank.Between(last, ""); err != nil {
+		return fmt.Errorf("failed to generate task rank: %w", err)
+	}
+
 	query := `
-		INSERT INTO tasks (user_id, title, description, status)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO tasks (user_id, parent_task_id, project_id, assignee_id, series_id, occurrence_at, title, description, status, priority, start_at, due_at, board_rank)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.Exec(query, task.UserID, task.Title, task.Description, task.Status)
+	result, err := tx.Exec(query, task.UserID, task.ParentTaskID, task.ProjectID, task.AssigneeID, task.SeriesID, task.OccurrenceAt, task.Title, task.Description, task.Status, task.Priority, task.StartAt, task.DueAt, task.Rank)
 	if err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
 	}
@@ -44,31 +199,33 @@ func (r *taskRepository) Create(task *model.Task) error {
 		return fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task create: %w", err)
+	}
+
 	task.ID = int(id)
 	return nil
 }
 
-// GetByID mengambil task berdasarkan ID
+// GetByID mengambil task berdasarkan ID, task di trash dianggap tidak ada
 func (r *taskRepository) GetByID(id int) (*model.Task, error) {
-	query := `
-		SELECT id, user_id, title, description, status, created_at, updated_at
-		FROM tasks
-		WHERE id = ?
-	`
+	return r.getOne("t.id = ? AND t.deleted_at IS NULL", id)
+}
 
-	var task model.Task
-	var description sql.NullString
-	
-	err := r.db.QueryRow(query, id).Scan(
-		&task.ID,
-		&task.UserID,
-		&task.Title,
-		&description,
-		&task.Status,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-	)
+// GetDeletedByID mengambil task di trash berdasarkan ID
+func (r *taskRepository) GetDeletedByID(id int) (*model.Task, error) {
+	return r.getOne("t.id = ? AND t.deleted_at IS NOT NULL", id)
+}
+
+// getOne mengambil satu task yang memenuhi kondisi beserta labels-nya
+func (r *taskRepository) getOne(condition string, args ...interface{}) (*model.Task, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		WHERE %s
+	`, taskColumns, condition)
 
+	task, err := scanTask(r.db.QueryRow(query, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -76,186 +233,347 @@ func (r *taskRepository) GetByID(id int) (*model.Task, error) {
 		return nil, fmt.Errorf("failed to get task by id: %w", err)
 	}
 
-	if description.Valid {
-		task.Description = &description.String
+	tasks := []model.Task{*task}
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, err
+	}
+	if err := r.attachDependencies(tasks); err != nil {
+		return nil, err
 	}
 
-	return &task, nil
+	return &tasks[0], nil
 }
 
-// GetByUserID mengambil tasks berdasarkan user ID dengan pagination dan filter
-func (r *taskRepository) GetByUserID(userID int, page, limit int, status, search string) ([]model.Task, int, error) {
-	offset := (page - 1) * limit
-	
-	// Build query with filters
-	whereClause := "WHERE user_id = ?"
-	args := []interface{}{userID}
-	
-	if status != "" {
-		whereClause += " AND status = ?"
-		args = append(args, status)
-	}
-	
-	if search != "" {
-		whereClause += " AND (title LIKE ? OR description LIKE ?)"
-		searchPattern := "%" + search + "%"
-		args = append(args, searchPattern, searchPattern)
+// GetByUserID mengambil tasks milik atau yang di-assign ke user dengan pagination dan filter
+func (r *taskRepository) GetByUserID(userID int, page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error) {
+	result, err := r.list([]string{"(t.user_id = ? OR t.assignee_id = ?)"}, []interface{}{userID, userID}, page, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetAll mengambil semua tasks dengan pagination dan filter (admin only)
+func (r *taskRepository) GetAll(page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error) {
+	result, err := r.list(nil, nil, page, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+// list menjalankan query list tasks dengan kondisi dasar ditambah filter.
+// Satu baris ekstra diambil untuk mengetahui apakah masih ada halaman
+// berikutnya tanpa COUNT(*). Dengan cursor, halaman dimulai tepat setelah
+// (atau sebelum) posisi (created_at, id) sehingga tidak ada duplikat atau
+// task terlewat saat task baru ditambahkan.
+func (r *taskRepository) list(conditions []string, args []interface{}, page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error) {
+	conditions, args, err := taskConditions(conditions, args, filter)
+	if err != nil {
+		return nil, err
+	}
+	countWhere := "WHERE " + strings.Join(conditions, " AND ")
+	countArgs := args
+
+	orderClause, ok := taskSortClauses[filter.Sort]
+	if !ok {
+		orderClause = taskSortClauses[model.TaskSortNewest]
+	}
+	if len(filter.SortFields) > 0 {
+		// id sebagai urutan terakhir agar hasil pagination stabil
+		sortFields := filter.SortFields
+		if !hasSortField(sortFields, "id") {
+			sortFields = append(sortFields[:len(sortFields):len(sortFields)], filterql.SortField{Field: "id", Desc: true})
+		}
+		clause, err := filterql.OrderBy(sortFields, taskFilterColumns)
+		if err != nil {
+			return nil, err
+		}
+		orderClause = clause
+	}
+
+	// Relevansi adalah skor judul/deskripsi ditambah skor komentar terbaik
+	var orderArgs []interface{}
+	searchQuery := search.Parse(filter.Search)
+	if filter.Sort == model.TaskSortRelevance && !searchQuery.IsEmpty() {
+		orderClause = `MATCH(t.title, t.description) AGAINST (? IN BOOLEAN MODE) +
+			COALESCE((SELECT MAX(MATCH(c.body) AGAINST (? IN BOOLEAN MODE)) FROM task_comments c WHERE c.task_id = t.id), 0) DESC,
+			t.created_at DESC, t.id DESC`
+		orderArgs = []interface{}{searchQuery.BooleanMode(), searchQuery.BooleanMode()}
+	}
+
+	limitClause := "LIMIT ? OFFSET ?"
+	limitArgs := []interface{}{page.Limit + 1, (page.Page - 1) * page.Limit}
+
+	if page.Cursor != nil {
+		// Halaman sebelumnya diambil dengan urutan terbalik lalu dibalik lagi
+		ascending := filter.Sort == model.TaskSortOldest
+		if page.Cursor.Backward {
+			ascending = !ascending
+		}
+
+		operator, direction := "<", "DESC"
+		if ascending {
+			operator, direction = ">", "ASC"
+		}
+
+		conditions = append(conditions, fmt.Sprintf("(t.created_at %s ? OR (t.created_at = ? AND t.id %s ?))", operator, operator))
+		args = append(args, page.Cursor.CreatedAt, page.Cursor.CreatedAt, page.Cursor.ID)
+		orderClause = fmt.Sprintf("t.created_at %s, t.id %s", direction, direction)
+		orderArgs = nil
+		limitClause = "LIMIT ?"
+		limitArgs = []interface{}{page.Limit + 1}
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, user_id, title, description, status, created_at, updated_at
-		FROM tasks
+		SELECT %s
+		FROM tasks t
+		WHERE %s
+		ORDER BY %s
 		%s
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
-	
-	args = append(args, limit, offset)
+	`, taskColumns, strings.Join(conditions, " AND "), orderClause, limitClause)
 
-	rows, err := r.db.Query(query, args...)
+	queryArgs := append(append(append([]interface{}{}, args...), orderArgs...), limitArgs...)
+
+	rows, err := r.db.Query(query, queryArgs...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
-	var tasks []model.Task
-	for rows.Next() {
-		var task model.Task
-		var description sql.NullString
-		
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Title,
-			&description,
-			&task.Status,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(tasks) > page.Limit
+	if hasMore {
+		tasks = tasks[:page.Limit]
+	}
+
+	result := &model.TaskPageResult{}
+	switch {
+	case page.Cursor == nil:
+		result.HasNext, result.HasPrev = hasMore, page.Page > 1
+	case page.Cursor.Backward:
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
 		}
-		
-		if description.Valid {
-			task.Description = &description.String
+		result.HasNext, result.HasPrev = true, hasMore
+	default:
+		result.HasNext, result.HasPrev = hasMore, true
+	}
+
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, err
+	}
+	if err := r.attachDependencies(tasks); err != nil {
+		return nil, err
+	}
+	result.Tasks = tasks
+
+	if filter.Search != "" && !searchQuery.IsEmpty() {
+		if result.MatchedComments, err = r.matchedComments(tasks, searchQuery); err != nil {
+			return nil, err
 		}
-		
-		tasks = append(tasks, task)
 	}
 
-	// Count total tasks
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks %s", whereClause)
-	countArgs := args[:len(args)-2] // Remove LIMIT and OFFSET
-	
-	var total int
-	err = r.db.QueryRow(countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	if page.IncludeTotal {
+		var total int
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks t %s", countWhere)
+		if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("failed to count tasks: %w", err)
+		}
+		result.Total = &total
 	}
 
-	return tasks, total, nil
+	return result, nil
 }
 
-// GetAll mengambil semua tasks dengan pagination dan filter (admin only)
-func (r *taskRepository) GetAll(page, limit int, status, search string) ([]model.Task, int, error) {
-	offset := (page - 1) * limit
-	
-	// Build query with filters
-	var whereClause string
-	var args []interface{}
-	
-	conditions := []string{}
-	
-	if status != "" {
-		conditions = append(conditions, "status = ?")
-		args = append(args, status)
-	}
-	
-	if search != "" {
-		conditions = append(conditions, "(title LIKE ? OR description LIKE ?)")
-		searchPattern := "%" + search + "%"
-		args = append(args, searchPattern, searchPattern)
+// hasSortField mengecek apakah field sudah ada di daftar sort
+func hasSortField(sorts []filterql.SortField, field string) bool {
+	for _, sort := range sorts {
+		if sort.Field == field {
+			return true
+		}
 	}
-	
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	return false
+}
+
+// matchedComments mengambil komentar paling relevan untuk setiap task yang
+// komentarnya cocok dengan pencarian
+func (r *taskRepository) matchedComments(tasks []model.Task, query search.Query) (map[int]string, error) {
+	if len(tasks) == 0 {
+		return nil, nil
 	}
 
-	query := fmt.Sprintf(`
-		SELECT id, user_id, title, description, status, created_at, updated_at
-		FROM tasks
-		%s
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
-	
-	args = append(args, limit, offset)
+	args := []interface{}{query.BooleanMode()}
+	for _, task := range tasks {
+		args = append(args, task.ID)
+	}
+	args = append(args, query.BooleanMode())
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.Query(fmt.Sprintf(`
+		SELECT c.task_id, c.body, MATCH(c.body) AGAINST (? IN BOOLEAN MODE) AS score
+		FROM task_comments c
+		WHERE c.task_id IN (%s) AND MATCH(c.body) AGAINST (? IN BOOLEAN MODE)
+		ORDER BY score DESC, c.id
+	`, placeholders(len(tasks))), args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get all tasks: %w", err)
+		return nil, fmt.Errorf("failed to get matched comments: %w", err)
 	}
 	defer rows.Close()
 
-	var tasks []model.Task
+	comments := make(map[int]string)
 	for rows.Next() {
-		var task model.Task
-		var description sql.NullString
-		
-		err := rows.Scan(
-			&task.ID,
-			&task.UserID,
-			&task.Title,
-			&description,
-			&task.Status,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
+		var taskID int
+		var body string
+		var score float64
+		if err := rows.Scan(&taskID, &body, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan matched comment: %w", err)
+		}
+		if _, exists := comments[taskID]; !exists {
+			comments[taskID] = body
+		}
+	}
+
+	return comments, rows.Err()
+}
+
+// taskConditions menambahkan kondisi dari filter beserta ekspresi ?filter=
+func taskConditions(conditions []string, args []interface{}, filter model.TaskFilter) ([]string, []interface{}, error) {
+	conditions, args = applyTaskFilter(conditions, args, filter)
+	if filter.Expr != nil {
+		clause, exprArgs, err := filterql.SQL(filter.Expr, taskFilterColumns)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan task: %w", err)
+			return nil, nil, err
 		}
-		
-		if description.Valid {
-			task.Description = &description.String
+		conditions = append(conditions, clause)
+		args = append(args, exprArgs...)
+	}
+	return conditions, args, nil
+}
+
+// applyTaskFilter menambahkan kondisi WHERE sesuai filter. Task di trash
+// tidak pernah ikut dalam list biasa.
+func applyTaskFilter(conditions []string, args []interface{}, filter model.TaskFilter) ([]string, []interface{}) {
+	conditions = append(conditions, "t.deleted_at IS NULL")
+
+	if filter.Status != "" {
+		conditions = append(conditions, "t.status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if filter.ProjectID != nil {
+		conditions = append(conditions, "t.project_id = ?")
+		args = append(args, *filter.ProjectID)
+	} else if !filter.IncludeArchived {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND p.archived = TRUE)")
+	}
+
+	if filter.AssignedTo != nil {
+		conditions = append(conditions, "t.assignee_id = ?")
+		args = append(args, *filter.AssignedTo)
+	}
+
+	if filter.Priority != "" {
+		conditions = append(conditions, "t.priority = ?")
+		args = append(args, filter.Priority)
+	}
+
+	if filter.Search != "" {
+		// Kata yang terlalu pendek untuk index FULLTEXT tetap dicari dengan LIKE
+		if query := search.Parse(filter.Search); !query.IsEmpty() {
+			conditions = append(conditions, "(MATCH(t.title, t.description) AGAINST (? IN BOOLEAN MODE) OR "+
+				"EXISTS (SELECT 1 FROM task_comments c WHERE c.task_id = t.id AND MATCH(c.body) AGAINST (? IN BOOLEAN MODE)))")
+			args = append(args, query.BooleanMode(), query.BooleanMode())
+		} else {
+			conditions = append(conditions, "(t.title LIKE ? OR t.description LIKE ?)")
+			searchPattern := "%" + filter.Search + "%"
+			args = append(args, searchPattern, searchPattern)
 		}
-		
-		tasks = append(tasks, task)
 	}
 
-	// Count total tasks
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks %s", whereClause)
-	countArgs := args[:len(args)-2] // Remove LIMIT and OFFSET
-	
-	var total int
-	err = r.db.QueryRow(countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count all tasks: %w", err)
+	if len(filter.Labels) > 0 {
+		labelSubquery := fmt.Sprintf(`
+			SELECT COUNT(DISTINCT l.name)
+			FROM task_labels tl
+			JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = t.id AND l.name IN (%s)
+		`, placeholders(len(filter.Labels)))
+
+		if filter.LabelMode == model.LabelMatchAll {
+			conditions = append(conditions, fmt.Sprintf("(%s) = ?", labelSubquery))
+			args = append(args, stringArgs(filter.Labels)...)
+			args = append(args, len(filter.Labels))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(%s) > 0", labelSubquery))
+			args = append(args, stringArgs(filter.Labels)...)
+		}
 	}
 
-	return tasks, total, nil
+	if filter.DueBefore != nil {
+		conditions = append(conditions, "t.due_at < ?")
+		args = append(args, *filter.DueBefore)
+	}
+
+	if filter.DueAfter != nil {
+		conditions = append(conditions, "t.due_at > ?")
+		args = append(args, *filter.DueAfter)
+	}
+
+	if filter.Overdue {
+		conditions = append(conditions, "t.due_at < ? AND NOT "+taskDoneCondition("t"))
+		args = append(args, time.Now())
+	}
+
+	return conditions, args
 }
 
-// Update mengupdate task
+// Update mengupdate task jika version-nya masih sama dengan task.Version,
+// lalu menaikkan version. ErrVersionConflict dikembalikan jika task sudah
+// diubah oleh request lain sejak dibaca.
 func (r *taskRepository) Update(task *model.Task) error {
+	// reminded_at direset saat due_at berubah agar reminder dikirim ulang.
+	// Urutan SET penting: MySQL mengevaluasi assignment dari kiri ke kanan.
 	query := `
 		UPDATE tasks
-		SET title = ?, description = ?, status = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE id = ?
+		SET reminded_at = IF(due_at <=> ?, reminded_at, NULL),
+			project_id = ?, assignee_id = ?, series_id = ?, occurrence_at = ?, title = ?, description = ?, status = ?, priority = ?,
+			start_at = ?, due_at = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND version = ? AND deleted_at IS NULL
 	`
 
-	_, err := r.db.Exec(query, task.Title, task.Description, task.Status, task.ID)
+	result, err := r.db.Exec(query, task.DueAt, task.ProjectID, task.AssigneeID, task.SeriesID, task.OccurrenceAt, task.Title, task.Description, task.Status, task.Priority, task.StartAt, task.DueAt, task.ID, task.Version)
 	if err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	task.Version++
 	return nil
 }
 
-// Delete menghapus task
-func (r *taskRepository) Delete(id int) error {
-	query := "DELETE FROM tasks WHERE id = ?"
+// SoftDelete memindahkan tasks ke trash. Semua task diberi deleted_at yang
+// sama agar bisa di-restore bersama.
+func (r *taskRepository) SoftDelete(ids []int, deletedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("UPDATE tasks SET deleted_at = ?, updated_at = updated_at WHERE deleted_at IS NULL AND id IN (%s)", placeholders(len(ids)))
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.Exec(query, append([]interface{}{deletedAt}, intArgs(ids)...)...)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -272,10 +590,123 @@ func (r *taskRepository) Delete(id int) error {
 	return nil
 }
 
+// Restore mengeluarkan task dari trash beserta subtasks yang ikut dihapus
+// bersamanya (deleted_at sama), lalu mengembalikan ID yang di-restore
+func (r *taskRepository) Restore(id int, deletedAt time.Time) ([]int, error) {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var restored []int
+	level := []int{id}
+	for len(level) > 0 {
+		query := fmt.Sprintf("UPDATE tasks SET deleted_at = NULL, updated_at = updated_at WHERE id IN (%s)", placeholders(len(level)))
+		if _, err := tx.Exec(query, intArgs(level)...); err != nil {
+			return nil, fmt.Errorf("failed to restore task: %w", err)
+		}
+		restored = append(restored, level...)
+
+		query = fmt.Sprintf("SELECT id FROM tasks WHERE deleted_at = ? AND parent_task_id IN (%s)", placeholders(len(level)))
+		rows, err := tx.Query(query, append([]interface{}{deletedAt}, intArgs(level)...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trashed subtasks: %w", err)
+		}
+		level, err = scanIDs(rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit task restore: %w", err)
+	}
+
+	return restored, nil
+}
+
+// GetTrash mengambil task di trash dengan pagination, terbaru dihapus lebih
+// dulu. Subtask yang ikut terhapus bersama parent-nya tidak ditampilkan
+// sendiri. userID nil berarti semua user (admin).
+func (r *taskRepository) GetTrash(userID *int, page, limit int) ([]model.Task, int, error) {
+	offset := (page - 1) * limit
+
+	conditions := []string{
+		"t.deleted_at IS NOT NULL",
+		"NOT EXISTS (SELECT 1 FROM tasks pt WHERE pt.id = t.parent_task_id AND pt.deleted_at IS NOT NULL)",
+	}
+	var args []interface{}
+	if userID != nil {
+		conditions = append(conditions, "t.user_id = ?")
+		args = append(args, *userID)
+	}
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		%s
+		ORDER BY t.deleted_at DESC, t.id DESC
+		LIMIT ? OFFSET ?
+	`, taskColumns, whereClause)
+
+	rows, err := r.db.Query(query, append(append([]interface{}{}, args...), limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get trash: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, 0, err
+	}
+	if err := r.attachDependencies(tasks); err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM tasks t %s", whereClause)
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count trash: %w", err)
+	}
+
+	return tasks, total, nil
+}
+
+// GetTrashedBefore mengambil ID task yang masuk trash sebelum waktu tertentu
+func (r *taskRepository) GetTrashedBefore(deletedBefore time.Time) ([]int, error) {
+	rows, err := r.db.Query("SELECT id FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trashed tasks: %w", err)
+	}
+
+	return scanIDs(rows)
+}
+
+// Purge menghapus tasks secara permanen. Subtasks, labels, komentar, dan
+// metadata attachment ikut terhapus oleh foreign key.
+func (r *taskRepository) Purge(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("DELETE FROM tasks WHERE id IN (%s)", placeholders(len(ids)))
+
+	if _, err := r.db.Exec(query, intArgs(ids)...); err != nil {
+		return fmt.Errorf("failed to purge tasks: %w", err)
+	}
+
+	return nil
+}
+
 // IsOwner mengecek apakah user adalah pemilik task
 func (r *taskRepository) IsOwner(taskID, userID int) (bool, error) {
-	query := "SELECT user_id FROM tasks WHERE id = ?"
-	
+	query := "SELECT user_id FROM tasks WHERE id = ? AND deleted_at IS NULL"
+
 	var ownerID int
 	err := r.db.QueryRow(query, taskID).Scan(&ownerID)
 	if err != nil {
@@ -286,4 +717,683 @@ func (r *taskRepository) IsOwner(taskID, userID int) (bool, error) {
 	}
 
 	return ownerID == userID, nil
-}
\ No newline at end of file
+}
+
+// attachLabels mengisi Labels untuk setiap task dengan satu query
+func (r *taskRepository) attachLabels(tasks []model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	taskIDs := make([]int, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	query := fmt.Sprintf(`
+		SELECT tl.task_id, %s
+		FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id IN (%s)
+		ORDER BY l.name ASC
+	`, labelColumns, placeholders(len(taskIDs)))
+
+	rows, err := r.db.Query(query, intArgs(taskIDs)...)
+	if err != nil {
+		return fmt.Errorf("failed to get task labels: %w", err)
+	}
+	defer rows.Close()
+
+	labelsByTask := make(map[int][]model.Label)
+	for rows.Next() {
+		var taskID int
+		var label model.Label
+		err := rows.Scan(
+			&taskID,
+			&label.ID,
+			&label.UserID,
+			&label.Name,
+			&label.Color,
+			&label.CreatedAt,
+			&label.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan task label: %w", err)
+		}
+		labelsByTask[taskID] = append(labelsByTask[taskID], label)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate task labels: %w", err)
+	}
+
+	for i := range tasks {
+		tasks[i].Labels = labelsByTask[tasks[i].ID]
+		if tasks[i].Labels == nil {
+			tasks[i].Labels = []model.Label{}
+		}
+	}
+
+	return nil
+}
+
+// attachDependencies mengisi BlockedBy dan Blocking untuk setiap task.
+// Task lawan yang ada di trash tidak ditampilkan.
+func (r *taskRepository) attachDependencies(tasks []model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	taskIDs := make([]int, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	query := fmt.Sprintf(`
+		SELECT d.task_id, d.blocker_id, t.deleted_at IS NULL, b.deleted_at IS NULL
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		JOIN tasks b ON b.id = d.blocker_id
+		WHERE d.task_id IN (%s) OR d.blocker_id IN (%s)
+		ORDER BY d.task_id ASC, d.blocker_id ASC
+	`, placeholders(len(taskIDs)), placeholders(len(taskIDs)))
+
+	args := append(intArgs(taskIDs), intArgs(taskIDs)...)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to get task dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	blockedBy := make(map[int][]int)
+	blocking := make(map[int][]int)
+	for rows.Next() {
+		var taskID, blockerID int
+		var taskActive, blockerActive bool
+		if err := rows.Scan(&taskID, &blockerID, &taskActive, &blockerActive); err != nil {
+			return fmt.Errorf("failed to scan task dependency: %w", err)
+		}
+		if blockerActive {
+			blockedBy[taskID] = append(blockedBy[taskID], blockerID)
+		}
+		if taskActive {
+			blocking[blockerID] = append(blocking[blockerID], taskID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate task dependencies: %w", err)
+	}
+
+	for i := range tasks {
+		tasks[i].BlockedBy = blockedBy[tasks[i].ID]
+		if tasks[i].BlockedBy == nil {
+			tasks[i].BlockedBy = []int{}
+		}
+		tasks[i].Blocking = blocking[tasks[i].ID]
+		if tasks[i].Blocking == nil {
+			tasks[i].Blocking = []int{}
+		}
+	}
+
+	return nil
+}
+
+// AddDependency menandai taskID terblokir oleh blockerID. Dependency yang
+// sudah ada diabaikan.
+func (r *taskRepository) AddDependency(taskID, blockerID int) error {
+	query := "INSERT IGNORE INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)"
+
+	if _, err := r.db.Exec(query, taskID, blockerID); err != nil {
+		return fmt.Errorf("failed to add task dependency: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveDependency menghapus blockerID dari blocker taskID
+func (r *taskRepository) RemoveDependency(taskID, blockerID int) error {
+	query := "DELETE FROM task_dependencies WHERE task_id = ? AND blocker_id = ?"
+
+	if _, err := r.db.Exec(query, taskID, blockerID); err != nil {
+		return fmt.Errorf("failed to remove task dependency: %w", err)
+	}
+
+	return nil
+}
+
+// GetBlockers mengambil blocker langsung dari setiap task, termasuk blocker
+// yang ada di trash karena bisa di-restore kembali
+func (r *taskRepository) GetBlockers(taskIDs []int) (map[int][]int, error) {
+	blockers := make(map[int][]int)
+	if len(taskIDs) == 0 {
+		return blockers, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT task_id, blocker_id
+		FROM task_dependencies
+		WHERE task_id IN (%s)
+	`, placeholders(len(taskIDs)))
+
+	rows, err := r.db.Query(query, intArgs(taskIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task blockers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, blockerID int
+		if err := rows.Scan(&taskID, &blockerID); err != nil {
+			return nil, fmt.Errorf("failed to scan task blocker: %w", err)
+		}
+		blockers[taskID] = append(blockers[taskID], blockerID)
+	}
+
+	return blockers, rows.Err()
+}
+
+// GetIncompleteBlockers mengambil ID blocker taskID yang statusnya belum
+// selesai (is_done) menurut workflow blocker
+func (r *taskRepository) GetIncompleteBlockers(taskID int) ([]int, error) {
+	query := fmt.Sprintf(`
+		SELECT d.blocker_id
+		FROM task_dependencies d
+		JOIN tasks b ON b.id = d.blocker_id
+		WHERE d.task_id = ? AND b.deleted_at IS NULL AND NOT %s
+		ORDER BY d.blocker_id ASC
+	`, taskDoneCondition("b"))
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incomplete blockers: %w", err)
+	}
+
+	return scanIDs(rows)
+}
+
+// SetLabels mengganti semua labels pada task
+func (r *taskRepository) SetLabels(taskID int, labelIDs []int) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM task_labels WHERE task_id = ?", taskID); err != nil {
+		return fmt.Errorf("failed to clear task labels: %w", err)
+	}
+
+	for _, labelID := range labelIDs {
+		_, err := tx.Exec("INSERT IGNORE INTO task_labels (task_id, label_id) VALUES (?, ?)", taskID, labelID)
+		if err != nil {
+			return fmt.Errorf("failed to add task label: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task labels: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubtasks mengambil subtasks langsung dari sebuah task
+func (r *taskRepository) GetSubtasks(parentID int) ([]model.Task, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		WHERE t.parent_task_id = ? AND t.deleted_at IS NULL
+		ORDER BY t.created_at ASC, t.id ASC
+	`, taskColumns)
+
+	rows, err := r.db.Query(query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, err
+	}
+	if err := r.attachDependencies(tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// ReparentSubtasks memindahkan subtasks langsung ke parent baru (nil = jadi task root)
+func (r *taskRepository) ReparentSubtasks(parentID int, newParentID *int) error {
+	query := "UPDATE tasks SET parent_task_id = ?, version = version + 1 WHERE parent_task_id = ?"
+
+	_, err := r.db.Exec(query, newParentID, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to reparent subtasks: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueForReminder mengambil task belum selesai yang jatuh tempo setelah
+// dueAfter sampai dueBefore dan belum pernah dikirimi reminder. Task yang
+// sudah lewat due date tidak diambil.
+func (r *taskRepository) GetDueForReminder(dueAfter, dueBefore time.Time) ([]model.Task, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		WHERE t.due_at > ?
+			AND t.due_at <= ?
+			AND t.reminded_at IS NULL
+			AND t.deleted_at IS NULL
+			AND NOT %s
+		ORDER BY t.due_at ASC
+	`, taskColumns, taskDoneCondition("t"))
+
+	rows, err := r.db.Query(query, dueAfter, dueBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks due for reminder: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTasks(rows)
+}
+
+// MarkReminded menandai task sudah dikirimi reminder
+func (r *taskRepository) MarkReminded(taskID int, remindedAt time.Time) error {
+	// updated_at dipertahankan karena reminder bukan perubahan oleh user
+	query := "UPDATE tasks SET reminded_at = ?, updated_at = updated_at WHERE id = ?"
+
+	_, err := r.db.Exec(query, remindedAt, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to mark task reminded: %w", err)
+	}
+
+	return nil
+}
+
+// GetSeriesOccurrences mengambil occurrence dalam series yang dijadwalkan
+// setelah waktu tertentu, urut dari yang paling awal. Occurrence di trash
+// ikut diambil agar tidak dibuat ulang.
+func (r *taskRepository) GetSeriesOccurrences(seriesID int, after time.Time) ([]model.Task, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		WHERE t.series_id = ? AND t.occurrence_at > ?
+		ORDER BY t.occurrence_at ASC
+	`, taskColumns)
+
+	rows, err := r.db.Query(query, seriesID, after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// LockTasks mengunci baris tasks dengan SELECT ... FOR UPDATE sampai
+// transaksi selesai. Baris dikunci urut id agar dua transaksi yang mengunci
+// task yang sama tidak saling deadlock. Hanya bermakna jika repository dibuat
+// dari *sql.Tx.
+func (r *taskRepository) LockTasks(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("SELECT id FROM tasks WHERE id IN (%s) ORDER BY id FOR UPDATE", placeholders(len(ids)))
+	rows, err := r.db.Query(query, intArgs(ids)...)
+	if err != nil {
+		return fmt.Errorf("failed to lock tasks: %w", err)
+	}
+	defer rows.Close()
+
+	// Hasil tidak dipakai, baris hanya dibaca sampai habis
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// ApplyBulk menerapkan bulk change ke semua task dalam satu transaksi
+func (r *taskRepository) ApplyBulk(change *model.TaskBulkChange) error {
+	if len(change.TaskIDs) == 0 {
+		return nil
+	}
+
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	in := placeholders(len(change.TaskIDs))
+	ids := intArgs(change.TaskIDs)
+
+	switch change.Operation {
+	case model.BulkUpdateStatus:
+		query := fmt.Sprintf("UPDATE tasks SET status = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id IN (%s)", in)
+		if _, err := tx.Exec(query, append([]interface{}{change.Status}, ids...)...); err != nil {
+			return fmt.Errorf("failed to update task status: %w", err)
+		}
+
+	case model.BulkMoveProject:
+		query := fmt.Sprintf("UPDATE tasks SET project_id = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id IN (%s)", in)
+		if _, err := tx.Exec(query, append([]interface{}{change.ProjectID}, ids...)...); err != nil {
+			return fmt.Errorf("failed to move tasks: %w", err)
+		}
+
+	case model.BulkAddLabel:
+		for _, taskID := range change.TaskIDs {
+			result, err := tx.Exec("INSERT IGNORE INTO task_labels (task_id, label_id) VALUES (?, ?)", taskID, change.LabelID)
+			if err != nil {
+				return fmt.Errorf("failed to add task label: %w", err)
+			}
+			if added, _ := result.RowsAffected(); added > 0 {
+				if _, err := tx.Exec("UPDATE tasks SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?", taskID); err != nil {
+					return fmt.Errorf("failed to update task version: %w", err)
+				}
+			}
+		}
+
+	case model.BulkDelete:
+		// Subtasks dipindahkan ke parent saat ini satu per satu, sehingga
+		// subtasks dari task yang parent-nya juga dihapus tetap berakhir di
+		// ancestor yang tidak dihapus
+		for _, taskID := range change.ReparentIDs {
+			_, err := tx.Exec(`
+				UPDATE tasks st
+				JOIN tasks t ON t.id = st.parent_task_id
+				SET st.parent_task_id = t.parent_task_id, st.version = st.version + 1
+				WHERE t.id = ?
+			`, taskID)
+			if err != nil {
+				return fmt.Errorf("failed to reparent subtasks: %w", err)
+			}
+		}
+
+		query := fmt.Sprintf("UPDATE tasks SET deleted_at = ?, updated_at = updated_at WHERE deleted_at IS NULL AND id IN (%s)", in)
+		if _, err := tx.Exec(query, append([]interface{}{change.DeletedAt}, ids...)...); err != nil {
+			return fmt.Errorf("failed to delete tasks: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown bulk operation %q", change.Operation)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bulk operation: %w", err)
+	}
+
+	return nil
+}
+
+// Move memindahkan task ke status dan posisi baru dalam satu transaksi.
+// Rank unik per pemilik task, sehingga hanya task dan rank terdekat milik
+// pemilik yang sama yang dikunci; perpindahan bersamaan ke celah yang sama
+// menunggu satu sama lain dan tidak menghasilkan rank yang sama. Rank
+// tetangga dan task lain di kolom tujuan hanya dibaca tanpa dikunci.
+func (r *taskRepository) Move(move *model.TaskMove) error {
+	err := r.move(move)
+	if isMySQLError(err, mysqlDuplicateEntry) || isMySQLError(err, mysqlDeadlock) {
+		return ErrRankConflict
+	}
+	return err
+}
+
+func (r *taskRepository) move(move *model.TaskMove) error {
+	tx, err := beginTx(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var version, ownerID int
+	err = tx.QueryRow("SELECT version, user_id FROM tasks WHERE id = ? AND deleted_at IS NULL FOR UPDATE", move.TaskID).Scan(&version, &ownerID)
+	if err == sql.ErrNoRows || (err == nil && version != move.Version) {
+		return ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+
+	var lower, upper string
+	if move.BeforeID != nil {
+		if lower, err = neighbourRank(tx, *move.BeforeID, move.Status); err != nil {
+			return err
+		}
+	}
+	if move.AfterID != nil {
+		if upper, err = neighbourRank(tx, *move.AfterID, move.Status); err != nil {
+			return err
+		}
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return ErrRankConflict
+	}
+
+	// Batas yang kosong dipersempit ke rank terdekat di kolom tujuan (agar
+	// posisi di board sesuai permintaan) dan rank terdekat milik pemilik
+	// task (agar rank baru tidak bentrok)
+	next := move.BeforeID != nil
+	from := upper
+	if next {
+		from = lower
+	}
+	ownerRank, err := ownerAdjacentRank(tx, ownerID, from, next, move.TaskID)
+	if err != nil {
+		return err
+	}
+	columnRank, err := columnAdjacentRank(tx, move.Status, from, next, move.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if next {
+		upper = nearerRank(nearerRank(upper, ownerRank, true), columnRank, true)
+	} else {
+		// Tanpa tetangga sama sekali, from kosong sehingga task ditaruh di
+		// akhir kolom sesudah semua rank pemiliknya
+		lower = nearerRank(ownerRank, columnRank, false)
+	}
+
+	newRank, err := rank.Between(lower, upper)
+	if err != nil {
+		return fmt.Errorf("failed to generate task rank: %w", err)
+	}
+
+	query := `
+		UPDATE tasks
+		SET status = ?, board_rank = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	if _, err := tx.Exec(query, move.Status, newRank, move.TaskID); err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit task move: %w", err)
+	}
+
+	return nil
+}
+
+// neighbourRank mengembalikan rank task tetangga. Tetangga bisa milik user
+// lain (task yang di-assign), sehingga tidak dikunci. ErrRankConflict
+// dikembalikan jika tetangga sudah tidak berada di status tujuan, misalnya
+// karena dipindahkan oleh request lain.
+func neighbourRank(tx DBTX, id int, status model.TaskStatus) (string, error) {
+	var neighbourStatus model.TaskStatus
+	var current string
+
+	query := "SELECT status, board_rank FROM tasks WHERE id = ? AND deleted_at IS NULL"
+	err := tx.QueryRow(query, id).Scan(&neighbourStatus, &current)
+	if err == sql.ErrNoRows {
+		return "", ErrRankConflict
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get neighbour task: %w", err)
+	}
+	if neighbourStatus != status {
+		return "", ErrRankConflict
+	}
+
+	return current, nil
+}
+
+// ownerAdjacentRank mengunci dan mengembalikan rank terdekat milik userID
+// sesudah (next) atau sebelum from, tanpa task excludeID. from kosong
+// berarti dari ujung list. String kosong dikembalikan jika tidak ada rank di
+// arah tersebut.
+func ownerAdjacentRank(tx DBTX, userID int, from string, next bool, excludeID int) (string, error) {
+	return adjacentRank(tx, "user_id = ?", userID, from, next, excludeID, "FOR UPDATE")
+}
+
+// columnAdjacentRank seperti ownerAdjacentRank untuk task dengan status
+// tertentu milik user mana pun, tanpa mengunci
+func columnAdjacentRank(tx DBTX, status model.TaskStatus, from string, next bool, excludeID int) (string, error) {
+	return adjacentRank(tx, "status = ? AND deleted_at IS NULL", status, from, next, excludeID, "")
+}
+
+func adjacentRank(tx DBTX, scope string, scopeArg interface{}, from string, next bool, excludeID int, lock string) (string, error) {
+	conditions := []string{scope, "id <> ?"}
+	args := []interface{}{scopeArg, excludeID}
+
+	operator, direction := "<", "DESC"
+	if next {
+		operator, direction = ">", "ASC"
+	}
+	if from != "" {
+		conditions = append(conditions, "board_rank "+operator+" ?")
+		args = append(args, from)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT board_rank
+		FROM tasks
+		WHERE %s
+		ORDER BY board_rank %s
+		LIMIT 1
+		%s
+	`, strings.Join(conditions, " AND "), direction, lock)
+
+	var adjacent string
+	err := tx.QueryRow(query, args...).Scan(&adjacent)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get adjacent rank: %w", err)
+	}
+
+	return adjacent, nil
+}
+
+// nearerRank memilih batas yang lebih sempit dari dua rank: yang lebih kecil
+// untuk batas atas (upper) atau yang lebih besar untuk batas bawah. String
+// kosong berarti tanpa batas.
+func nearerRank(a, b string, upper bool) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	case (a < b) == upper:
+		return a
+	}
+	return b
+}
+
+// GetBoardColumn mengambil task dengan status tertentu urut berdasarkan rank.
+// userID nil berarti semua user (admin). Nilai bool menandakan masih ada
+// task setelah limit.
+func (r *taskRepository) GetBoardColumn(userID *int, status model.TaskStatus, filter model.TaskFilter, limit int) ([]model.Task, bool, error) {
+	conditions, args := boardConditions(userID)
+	conditions = append(conditions, "t.status = ?")
+	args = append(args, status)
+
+	conditions, args, err := taskConditions(conditions, args, filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		WHERE %s
+		ORDER BY t.board_rank ASC, t.id ASC
+		LIMIT ?
+	`, taskColumns, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.Query(query, append(args, limit+1)...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get board tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(tasks) > limit
+	if hasMore {
+		tasks = tasks[:limit]
+	}
+
+	if err := r.attachLabels(tasks); err != nil {
+		return nil, false, err
+	}
+	if err := r.attachDependencies(tasks); err != nil {
+		return nil, false, err
+	}
+
+	return tasks, hasMore, nil
+}
+
+// GetBoardStatuses mengambil status yang dipakai task pada board
+func (r *taskRepository) GetBoardStatuses(userID *int, filter model.TaskFilter) ([]model.TaskStatus, error) {
+	conditions, args := boardConditions(userID)
+
+	conditions, args, err := taskConditions(conditions, args, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT DISTINCT t.status FROM tasks t WHERE %s ORDER BY t.status", strings.Join(conditions, " AND "))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []model.TaskStatus
+	for rows.Next() {
+		var status model.TaskStatus
+		if err := rows.Scan(&status); err != nil {
+			return nil, fmt.Errorf("failed to scan board status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, rows.Err()
+}
+
+// boardConditions membatasi board pada task milik atau yang di-assign ke user
+func boardConditions(userID *int) ([]string, []interface{}) {
+	if userID == nil {
+		return nil, nil
+	}
+	return []string{"(t.user_id = ? OR t.assignee_id = ?)"}, []interface{}{*userID, *userID}
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type TemplateRepository interface {
+	Create(template *model.TaskTemplate) error
+	GetByID(id int) (*model.TaskTemplate, error)
+	GetByUserID(userID int) ([]model.TaskTemplate, error)
+	Update(template *model.TaskTemplate) error
+	Delete(id int) error
+}
+
+// templateColumns adalah kolom yang dipilih untuk setiap query template.
+// Status dan priority NULL dibaca sebagai string kosong.
+const templateColumns = `tt.id, tt.user_id, tt.name, tt.title, tt.description,
+	COALESCE(tt.status, ''), COALESCE(tt.priority, ''), tt.created_at, tt.updated_at`
+
+type templateRepository struct {
+	db *sql.DB
+}
+
+// NewTemplateRepository membuat instance TemplateRepository
+func NewTemplateRepository(db *sql.DB) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+// scanTemplate membaca satu baris template sesuai urutan templateColumns
+func scanTemplate(row rowScanner) (*model.TaskTemplate, error) {
+	var template model.TaskTemplate
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.Title,
+		&template.Description,
+		&template.Status,
+		&template.Priority,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// Create membuat template beserta label dan subtask-nya dalam satu transaksi
+func (r *templateRepository) Create(template *model.TaskTemplate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"INSERT INTO task_templates (user_id, name, title, description, status, priority) VALUES (?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''))",
+		template.UserID, template.Name, template.Title, template.Description, template.Status, template.Priority,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	template.ID = int(id)
+
+	if err := insertTemplateDetails(tx, template); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit template: %w", err)
+	}
+
+	return nil
+}
+
+// insertTemplateDetails menyimpan label dan subtask template sesuai urutan
+func insertTemplateDetails(tx *sql.Tx, template *model.TaskTemplate) error {
+	for _, labelID := range template.LabelIDs {
+		_, err := tx.Exec("INSERT IGNORE INTO task_template_labels (template_id, label_id) VALUES (?, ?)", template.ID, labelID)
+		if err != nil {
+			return fmt.Errorf("failed to add template label: %w", err)
+		}
+	}
+
+	for i, subtask := range template.Subtasks {
+		_, err := tx.Exec(
+			"INSERT INTO task_template_subtasks (template_id, position, title, description) VALUES (?, ?, ?, ?)",
+			template.ID, i, subtask.Title, subtask.Description,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add template subtask: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByID mengambil template berdasarkan ID
+func (r *templateRepository) GetByID(id int) (*model.TaskTemplate, error) {
+	query := fmt.Sprintf("SELECT %s FROM task_templates tt WHERE tt.id = ?", templateColumns)
+
+	template, err := scanTemplate(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	templates := []model.TaskTemplate{*template}
+	if err := r.attachDetails(templates); err != nil {
+		return nil, err
+	}
+
+	return &templates[0], nil
+}
+
+// GetByUserID mengambil semua template milik user urut berdasarkan nama
+func (r *templateRepository) GetByUserID(userID int) ([]model.TaskTemplate, error) {
+	query := fmt.Sprintf("SELECT %s FROM task_templates tt WHERE tt.user_id = ? ORDER BY tt.name ASC, tt.id ASC", templateColumns)
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []model.TaskTemplate
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, *template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate templates: %w", err)
+	}
+
+	if err := r.attachDetails(templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// attachDetails mengisi LabelIDs dan Subtasks untuk setiap template
+func (r *templateRepository) attachDetails(templates []model.TaskTemplate) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(templates))
+	index := make(map[int]int, len(templates))
+	for i, template := range templates {
+		ids[i] = template.ID
+		index[template.ID] = i
+		templates[i].LabelIDs = []int{}
+		templates[i].Subtasks = []model.TemplateSubtask{}
+	}
+
+	labelQuery := fmt.Sprintf(`
+		SELECT template_id, label_id
+		FROM task_template_labels
+		WHERE template_id IN (%s)
+		ORDER BY template_id, label_id
+	`, placeholders(len(ids)))
+
+	rows, err := r.db.Query(labelQuery, intArgs(ids)...)
+	if err != nil {
+		return fmt.Errorf("failed to get template labels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var templateID, labelID int
+		if err := rows.Scan(&templateID, &labelID); err != nil {
+			return fmt.Errorf("failed to scan template label: %w", err)
+		}
+		i := index[templateID]
+		templates[i].LabelIDs = append(templates[i].LabelIDs, labelID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate template labels: %w", err)
+	}
+
+	subtaskQuery := fmt.Sprintf(`
+		SELECT template_id, title, description
+		FROM task_template_subtasks
+		WHERE template_id IN (%s)
+		ORDER BY template_id, position
+	`, placeholders(len(ids)))
+
+	subtaskRows, err := r.db.Query(subtaskQuery, intArgs(ids)...)
+	if err != nil {
+		return fmt.Errorf("failed to get template subtasks: %w", err)
+	}
+	defer subtaskRows.Close()
+
+	for subtaskRows.Next() {
+		var templateID int
+		var subtask model.TemplateSubtask
+		if err := subtaskRows.Scan(&templateID, &subtask.Title, &subtask.Description); err != nil {
+			return fmt.Errorf("failed to scan template subtask: %w", err)
+		}
+		i := index[templateID]
+		templates[i].Subtasks = append(templates[i].Subtasks, subtask)
+	}
+	if err := subtaskRows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate template subtasks: %w", err)
+	}
+
+	return nil
+}
+
+// Update mengganti isi template beserta label dan subtask-nya dalam satu transaksi
+func (r *templateRepository) Update(template *model.TaskTemplate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE task_templates
+		SET name = ?, title = ?, description = ?, status = NULLIF(?, ''), priority = NULLIF(?, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, template.Name, template.Title, template.Description, template.Status, template.Priority, template.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM task_template_labels WHERE template_id = ?", template.ID); err != nil {
+		return fmt.Errorf("failed to clear template labels: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM task_template_subtasks WHERE template_id = ?", template.ID); err != nil {
+		return fmt.Errorf("failed to clear template subtasks: %w", err)
+	}
+
+	if err := insertTemplateDetails(tx, template); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit template: %w", err)
+	}
+
+	return nil
+}
+
+// Delete menghapus template, label dan subtask ikut terhapus lewat foreign key
+func (r *templateRepository) Delete(id int) error {
+	query := "DELETE FROM task_templates WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// DBTX diimplementasikan oleh *sql.DB dan *sql.Tx sehingga repository bisa
+// dipakai di dalam maupun di luar transaksi
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// txHandle adalah transaksi yang dipakai method repository
+type txHandle interface {
+	DBTX
+	Commit() error
+	Rollback() error
+}
+
+// joinedTx membungkus transaksi yang sudah berjalan. Commit dan rollback
+// diserahkan ke pemilik transaksi.
+type joinedTx struct {
+	DBTX
+}
+
+func (joinedTx) Commit() error   { return nil }
+func (joinedTx) Rollback() error { return nil }
+
+// beginTx memulai transaksi baru, atau ikut transaksi yang sedang berjalan
+// jika repository dibuat dari *sql.Tx
+func beginTx(db DBTX) (txHandle, error) {
+	conn, ok := db.(*sql.DB)
+	if !ok {
+		return joinedTx{db}, nil
+	}
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// TxRepositories berisi repository yang terikat ke satu transaksi
+type TxRepositories struct {
+	Tasks      TaskRepository
+	Activities ActivityRepository
+	Series     SeriesRepository
+}
+
+// Transactor menjalankan beberapa operasi repository dalam satu transaksi
+type Transactor interface {
+	// WithinTx melakukan commit jika fn berhasil dan rollback jika fn
+	// mengembalikan error
+	WithinTx(fn func(repos TxRepositories) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new transactor
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// joinedTransactor menjalankan fn dengan repository dari transaksi yang
+// sudah berjalan
+type joinedTransactor struct {
+	repos TxRepositories
+}
+
+// JoinTx mengembalikan Transactor yang ikut transaksi milik repos, sehingga
+// WithinTx di dalamnya tidak membuka transaksi baru
+func JoinTx(repos TxRepositories) Transactor {
+	return joinedTransactor{repos: repos}
+}
+
+func (t joinedTransactor) WithinTx(fn func(repos TxRepositories) error) error {
+	return fn(t.repos)
+}
+
+func (t *transactor) WithinTx(fn func(repos TxRepositories) error) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = fn(TxRepositories{
+		Tasks:      NewTaskRepository(tx),
+		Activities: NewActivityRepository(tx),
+		Series:     NewSeriesRepository(tx),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+type WorkflowRepository interface {
+	Create(workflow *model.Workflow) error
+	GetByID(id int) (*model.Workflow, error)
+	GetDefault() (*model.Workflow, error)
+	GetAll() ([]model.Workflow, error)
+	Update(workflow *model.Workflow) error
+	Delete(id int) error
+}
+
+// workflowColumns adalah kolom yang dipilih untuk setiap query workflow
+const workflowColumns = "w.id, w.name, w.is_default, w.created_at, w.updated_at"
+
+type workflowRepository struct {
+	db *sql.DB
+}
+
+// NewWorkflowRepository membuat instance WorkflowRepository
+func NewWorkflowRepository(db *sql.DB) WorkflowRepository {
+	return &workflowRepository{db: db}
+}
+
+// scanWorkflow membaca satu baris workflow sesuai urutan workflowColumns
+func scanWorkflow(row rowScanner) (*model.Workflow, error) {
+	var workflow model.Workflow
+	err := row.Scan(
+		&workflow.ID,
+		&workflow.Name,
+		&workflow.IsDefault,
+		&workflow.CreatedAt,
+		&workflow.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflow, nil
+}
+
+// Create membuat workflow beserta status dan transisinya dalam satu transaksi
+func (r *workflowRepository) Create(workflow *model.Workflow) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("INSERT INTO workflows (name, is_default) VALUES (?, ?)", workflow.Name, workflow.IsDefault)
+	if err != nil {
+		return fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	workflow.ID = int(id)
+
+	if err := insertWorkflowDetails(tx, workflow); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit workflow: %w", err)
+	}
+
+	return nil
+}
+
+// insertWorkflowDetails menyimpan status dan transisi workflow
+func insertWorkflowDetails(tx *sql.Tx, workflow *model.Workflow) error {
+	for i, status := range workflow.Statuses {
+		_, err := tx.Exec(
+			"INSERT INTO workflow_statuses (workflow_id, status, name, is_initial, is_done, position) VALUES (?, ?, ?, ?, ?, ?)",
+			workflow.ID, status.Key, status.Name, status.IsInitial, status.IsDone, i,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add workflow status: %w", err)
+		}
+	}
+
+	for _, transition := range workflow.Transitions {
+		_, err := tx.Exec(
+			"INSERT INTO workflow_transitions (workflow_id, from_status, to_status) VALUES (?, ?, ?)",
+			workflow.ID, transition.From, transition.To,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add workflow transition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByID mengambil workflow berdasarkan ID
+func (r *workflowRepository) GetByID(id int) (*model.Workflow, error) {
+	query := fmt.Sprintf("SELECT %s FROM workflows w WHERE w.id = ?", workflowColumns)
+	return r.getOne(query, id)
+}
+
+// GetDefault mengambil workflow default
+func (r *workflowRepository) GetDefault() (*model.Workflow, error) {
+	query := fmt.Sprintf("SELECT %s FROM workflows w WHERE w.is_default = TRUE ORDER BY w.id LIMIT 1", workflowColumns)
+	return r.getOne(query)
+}
+
+// getOne mengambil satu workflow beserta status dan transisinya
+func (r *workflowRepository) getOne(query string, args ...interface{}) (*model.Workflow, error) {
+	workflow, err := scanWorkflow(r.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	workflows := []model.Workflow{*workflow}
+	if err := r.attachDetails(workflows); err != nil {
+		return nil, err
+	}
+
+	return &workflows[0], nil
+}
+
+// GetAll mengambil semua workflow, workflow default lebih dulu
+func (r *workflowRepository) GetAll() ([]model.Workflow, error) {
+	query := fmt.Sprintf("SELECT %s FROM workflows w ORDER BY w.is_default DESC, w.name ASC", workflowColumns)
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var workflows []model.Workflow
+	for rows.Next() {
+		workflow, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workflow: %w", err)
+		}
+		workflows = append(workflows, *workflow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workflows: %w", err)
+	}
+
+	if err := r.attachDetails(workflows); err != nil {
+		return nil, err
+	}
+
+	return workflows, nil
+}
+
+// attachDetails mengisi Statuses dan Transitions untuk setiap workflow
+func (r *workflowRepository) attachDetails(workflows []model.Workflow) error {
+	if len(workflows) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(workflows))
+	index := make(map[int]int, len(workflows))
+	for i, workflow := range workflows {
+		ids[i] = workflow.ID
+		index[workflow.ID] = i
+		workflows[i].Statuses = []model.WorkflowStatus{}
+		workflows[i].Transitions = []model.WorkflowTransition{}
+	}
+
+	statusQuery := fmt.Sprintf(`
+		SELECT workflow_id, status, name, is_initial, is_done
+		FROM workflow_statuses
+		WHERE workflow_id IN (%s)
+		ORDER BY workflow_id, position
+	`, placeholders(len(ids)))
+
+	rows, err := r.db.Query(statusQuery, intArgs(ids)...)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow statuses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workflowID int
+		var status model.WorkflowStatus
+		if err := rows.Scan(&workflowID, &status.Key, &status.Name, &status.IsInitial, &status.IsDone); err != nil {
+			return fmt.Errorf("failed to scan workflow status: %w", err)
+		}
+		i := index[workflowID]
+		workflows[i].Statuses = append(workflows[i].Statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate workflow statuses: %w", err)
+	}
+
+	transitionQuery := fmt.Sprintf(`
+		SELECT workflow_id, from_status, to_status
+		FROM workflow_transitions
+		WHERE workflow_id IN (%s)
+		ORDER BY workflow_id, from_status, to_status
+	`, placeholders(len(ids)))
+
+	transitionRows, err := r.db.Query(transitionQuery, intArgs(ids)...)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow transitions: %w", err)
+	}
+	defer transitionRows.Close()
+
+	for transitionRows.Next() {
+		var workflowID int
+		var transition model.WorkflowTransition
+		if err := transitionRows.Scan(&workflowID, &transition.From, &transition.To); err != nil {
+			return fmt.Errorf("failed to scan workflow transition: %w", err)
+		}
+		i := index[workflowID]
+		workflows[i].Transitions = append(workflows[i].Transitions, transition)
+	}
+	if err := transitionRows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate workflow transitions: %w", err)
+	}
+
+	return nil
+}
+
+// Update mengganti nama, status, dan transisi workflow dalam satu transaksi
+func (r *workflowRepository) Update(workflow *model.Workflow) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE workflows SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", workflow.Name, workflow.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	// Transisi ikut terhapus lewat foreign key ke workflow_statuses
+	if _, err := tx.Exec("DELETE FROM workflow_statuses WHERE workflow_id = ?", workflow.ID); err != nil {
+		return fmt.Errorf("failed to clear workflow statuses: %w", err)
+	}
+
+	if err := insertWorkflowDetails(tx, workflow); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit workflow: %w", err)
+	}
+
+	return nil
+}
+
+// Delete menghapus workflow, project yang memakainya kembali ke workflow default
+func (r *workflowRepository) Delete(id int) error {
+	query := "DELETE FROM workflows WHERE id = ?"
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrTimerRunning dikembalikan Create jika user sudah punya timer berjalan
+var ErrTimerRunning = errors.New("timer already running")
+
+// mysqlDuplicateEntry adalah kode error MySQL untuk pelanggaran unique key
+const mysqlDuplicateEntry = 1062
+
+type WorklogRepository interface {
+	Create(worklog *model.Worklog) error
+	GetByID(id int) (*model.Worklog, error)
+	GetRunning(userID int) (*model.Worklog, error)
+	Stop(id int, endedAt time.Time) error
+	GetByTaskID(taskID int, period model.WorklogRange) ([]model.Worklog, error)
+	Delete(id int) error
+	SumByUser(taskID int, period model.WorklogRange) ([]model.UserTime, error)
+	SumByTask(userID int, period model.WorklogRange) ([]model.TaskTime, error)
+}
+
+// worklogColumns adalah kolom yang dipilih untuk setiap query worklog
+const worklogColumns = "w.id, w.task_id, w.user_id, w.started_at, w.ended_at, w.note, w.created_at"
+
+// worklogSeconds menghitung durasi worklog, timer yang berjalan dihitung sampai sekarang
+const worklogSeconds = "TIMESTAMPDIFF(SECOND, w.started_at, COALESCE(w.ended_at, CURRENT_TIMESTAMP))"
+
+type worklogRepository struct {
+	db *sql.DB
+}
+
+// NewWorklogRepository membuat instance WorklogRepository
+func NewWorklogRepository(db *sql.DB) WorklogRepository {
+	return &worklogRepository{db: db}
+}
+
+// scanWorklog membaca satu baris worklog sesuai urutan worklogColumns
+func scanWorklog(row rowScanner) (*model.Worklog, error) {
+	var worklog model.Worklog
+	err := row.Scan(
+		&worklog.ID,
+		&worklog.TaskID,
+		&worklog.UserID,
+		&worklog.StartedAt,
+		&worklog.EndedAt,
+		&worklog.Note,
+		&worklog.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if worklog.EndedAt != nil {
+		end = *worklog.EndedAt
+	}
+	worklog.DurationSeconds = int(end.Sub(worklog.StartedAt).Seconds())
+
+	return &worklog, nil
+}
+
+// Create membuat worklog baru. Unique key pada timer yang berjalan menjamin
+// satu timer per user walaupun ada request bersamaan.
+func (r *worklogRepository) Create(worklog *model.Worklog) error {
+	query := "INSERT INTO task_worklogs (task_id, user_id, started_at, ended_at, note) VALUES (?, ?, ?, ?, ?)"
+
+	result, err := r.db.Exec(query, worklog.TaskID, worklog.UserID, worklog.StartedAt, worklog.EndedAt, worklog.Note)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			return ErrTimerRunning
+		}
+		return fmt.Errorf("failed to create worklog: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	worklog.ID = int(id)
+	return nil
+}
+
+// GetByID mengambil worklog berdasarkan ID
+func (r *worklogRepository) GetByID(id int) (*model.Worklog, error) {
+	return r.getOne("w.id = ?", id)
+}
+
+// GetRunning mengambil timer user yang masih berjalan, nil jika tidak ada
+func (r *worklogRepository) GetRunning(userID int) (*model.Worklog, error) {
+	return r.getOne("w.user_id = ? AND w.ended_at IS NULL", userID)
+}
+
+func (r *worklogRepository) getOne(condition string, args ...interface{}) (*model.Worklog, error) {
+	query := fmt.Sprintf("SELECT %s FROM task_worklogs w WHERE %s", worklogColumns, condition)
+
+	worklog, err := scanWorklog(r.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get worklog: %w", err)
+	}
+
+	return worklog, nil
+}
+
+// Stop menghentikan timer yang masih berjalan
+func (r *worklogRepository) Stop(id int, endedAt time.Time) error {
+	query := "UPDATE task_worklogs SET ended_at = ? WHERE id = ? AND ended_at IS NULL"
+
+	if _, err := r.db.Exec(query, endedAt, id); err != nil {
+		return fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTaskID mengambil worklog pada task dalam rentang waktu, terbaru dulu
+func (r *worklogRepository) GetByTaskID(taskID int, period model.WorklogRange) ([]model.Worklog, error) {
+	where, args := worklogConditions([]string{"w.task_id = ?"}, []interface{}{taskID}, period)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM task_worklogs w
+		WHERE %s
+		ORDER BY w.started_at DESC, w.id DESC
+	`, worklogColumns, where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worklogs: %w", err)
+	}
+	defer rows.Close()
+
+	worklogs := []model.Worklog{}
+	for rows.Next() {
+		worklog, err := scanWorklog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan worklog: %w", err)
+		}
+		worklogs = append(worklogs, *worklog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate worklogs: %w", err)
+	}
+
+	return worklogs, nil
+}
+
+// Delete menghapus worklog
+func (r *worklogRepository) Delete(id int) error {
+	if _, err := r.db.Exec("DELETE FROM task_worklogs WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete worklog: %w", err)
+	}
+
+	return nil
+}
+
+// SumByUser menghitung total waktu setiap user pada task
+func (r *worklogRepository) SumByUser(taskID int, period model.WorklogRange) ([]model.UserTime, error) {
+	where, args := worklogConditions([]string{"w.task_id = ?"}, []interface{}{taskID}, period)
+
+	query := fmt.Sprintf(`
+		SELECT w.user_id, CAST(SUM(%s) AS SIGNED)
+		FROM task_worklogs w
+		WHERE %s
+		GROUP BY w.user_id
+		ORDER BY w.user_id ASC
+	`, worklogSeconds, where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum worklogs by user: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []model.UserTime{}
+	for rows.Next() {
+		var total model.UserTime
+		if err := rows.Scan(&total.UserID, &total.Seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan user time: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// SumByTask menghitung total waktu user pada setiap task, waktu terbanyak dulu
+func (r *worklogRepository) SumByTask(userID int, period model.WorklogRange) ([]model.TaskTime, error) {
+	where, args := worklogConditions([]string{"w.user_id = ?"}, []interface{}{userID}, period)
+
+	query := fmt.Sprintf(`
+		SELECT w.task_id, t.title, CAST(SUM(%s) AS SIGNED) AS seconds
+		FROM task_worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		WHERE %s
+		GROUP BY w.task_id, t.title
+		ORDER BY seconds DESC, w.task_id ASC
+	`, worklogSeconds, where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum worklogs by task: %w", err)
+	}
+	defer rows.Close()
+
+	totals := []model.TaskTime{}
+	for rows.Next() {
+		var total model.TaskTime
+		if err := rows.Scan(&total.TaskID, &total.Title, &total.Seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan task time: %w", err)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+// worklogConditions menambahkan batas rentang waktu pada started_at
+func worklogConditions(conditions []string, args []interface{}, period model.WorklogRange) (string, []interface{}) {
+	if period.From != nil {
+		conditions = append(conditions, "w.started_at >= ?")
+		args = append(args, *period.From)
+	}
+	if period.To != nil {
+		conditions = append(conditions, "w.started_at < ?")
+		args = append(args, *period.To)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func SetupRoutes(authHandler *handler.AuthHandler, oauthHandler *handler.OAuthHandler, taskHandler *handler.TaskHandler, adminHandler *handler.AdminHandler, jwtManager *jwt.JWTManager, corsConfig *config.CORSConfig) http.Handler {
+func SetupRoutes(authHandler *handler.AuthHandler, oauthHandler *handler.OAuthHandler, taskHandler *handler.TaskHandler, labelHandler *handler.LabelHandler, projectHandler *handler.ProjectHandler, commentHandler *handler.CommentHandler, attachmentHandler *handler.AttachmentHandler, workflowHandler *handler.WorkflowHandler, worklogHandler *handler.WorklogHandler, templateHandler *handler.TemplateHandler, calendarHandler *handler.CalendarHandler, adminHandler *handler.AdminHandler, jwtManager *jwt.JWTManager, corsConfig *config.CORSConfig) http.Handler {
 	r := mux.NewRouter()
 
 	// Apply global middleware - CORS must be first
@@ -39,6 +39,10 @@ func SetupRoutes(authHandler *handler.AuthHandler, oauthHandler *handler.OAuthHa
 	oauth.HandleFunc("/google", oauthHandler.GoogleAuth).Methods("GET", "OPTIONS")
 	oauth.HandleFunc("/google/callback", oauthHandler.GoogleCallback).Methods("GET", "OPTIONS")
 
+	// Feed kalender memakai token rahasia pada URL karena aplikasi kalender
+	// tidak bisa mengirim bearer token
+	api.HandleFunc("/calendar/{token:[A-Za-z0-9_-]+}.ics", calendarHandler.GetFeed).Methods("GET", "OPTIONS")
+
 	// Protected routes (perlu authentication)
 	protected := api.PathPrefix("").Subrouter()
 	protected.Use(middleware.AuthMiddleware(jwtManager))
@@ -48,15 +52,86 @@ func SetupRoutes(authHandler *handler.AuthHandler, oauthHandler *handler.OAuthHa
 	tasks := protected.PathPrefix("/tasks").Subrouter()
 	tasks.HandleFunc("", taskHandler.GetTasks).Methods("GET", "OPTIONS")
 	tasks.HandleFunc("", taskHandler.CreateTask).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/bulk", taskHandler.BulkUpdateTasks).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/trash", taskHandler.GetTrash).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/export", taskHandler.ExportTasks).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/import", taskHandler.ImportTasks).Methods("POST", "OPTIONS")
 	tasks.HandleFunc("/{id:[0-9]+}", taskHandler.GetTaskByID).Methods("GET", "OPTIONS")
 	tasks.HandleFunc("/{id:[0-9]+}", taskHandler.UpdateTask).Methods("PUT", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}", taskHandler.PatchTask).Methods("PATCH", "OPTIONS")
 	tasks.HandleFunc("/{id:[0-9]+}", taskHandler.DeleteTask).Methods("DELETE", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/restore", taskHandler.RestoreTask).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/subtasks", taskHandler.GetSubtasks).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/subtasks", taskHandler.CreateSubtask).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/occurrences", taskHandler.GenerateOccurrences).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/dependencies", taskHandler.AddDependency).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/dependencies/{blockerId:[0-9]+}", taskHandler.RemoveDependency).Methods("DELETE", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/move", taskHandler.MoveTask).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/checklist/{index:[0-9]+}", taskHandler.ToggleChecklistItem).Methods("PUT", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/activity", taskHandler.GetTaskActivity).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/comments", commentHandler.GetComments).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/comments", commentHandler.CreateComment).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}", commentHandler.UpdateComment).Methods("PUT", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/comments/{commentId:[0-9]+}", commentHandler.DeleteComment).Methods("DELETE", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/attachments", attachmentHandler.GetAttachments).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/attachments", attachmentHandler.UploadAttachment).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/attachments/{attachmentId:[0-9]+}", attachmentHandler.DownloadAttachment).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/attachments/{attachmentId:[0-9]+}", attachmentHandler.DeleteAttachment).Methods("DELETE", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/timer/start", worklogHandler.StartTimer).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/timer/stop", worklogHandler.StopTimer).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/worklogs", worklogHandler.GetWorklogs).Methods("GET", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/worklogs", worklogHandler.CreateWorklog).Methods("POST", "OPTIONS")
+	tasks.HandleFunc("/{id:[0-9]+}/worklogs/{worklogId:[0-9]+}", worklogHandler.DeleteWorklog).Methods("DELETE", "OPTIONS")
+
+	// Time tracking routes (perlu authentication)
+	protected.HandleFunc("/timer", worklogHandler.GetRunningTimer).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/worklogs/summary", worklogHandler.GetSummary).Methods("GET", "OPTIONS")
+
+	// Board routes (perlu authentication)
+	protected.HandleFunc("/board", taskHandler.GetBoard).Methods("GET", "OPTIONS")
+
+	// Label routes (perlu authentication)
+	labels := protected.PathPrefix("/labels").Subrouter()
+	labels.HandleFunc("", labelHandler.GetLabels).Methods("GET", "OPTIONS")
+	labels.HandleFunc("", labelHandler.CreateLabel).Methods("POST", "OPTIONS")
+	labels.HandleFunc("/{id:[0-9]+}", labelHandler.UpdateLabel).Methods("PUT", "OPTIONS")
+	labels.HandleFunc("/{id:[0-9]+}", labelHandler.DeleteLabel).Methods("DELETE", "OPTIONS")
+
+	// Template routes (perlu authentication)
+	templates := protected.PathPrefix("/templates").Subrouter()
+	templates.HandleFunc("", templateHandler.GetTemplates).Methods("GET", "OPTIONS")
+	templates.HandleFunc("", templateHandler.CreateTemplate).Methods("POST", "OPTIONS")
+	templates.HandleFunc("/{id:[0-9]+}", templateHandler.GetTemplateByID).Methods("GET", "OPTIONS")
+	templates.HandleFunc("/{id:[0-9]+}", templateHandler.UpdateTemplate).Methods("PUT", "OPTIONS")
+	templates.HandleFunc("/{id:[0-9]+}", templateHandler.DeleteTemplate).Methods("DELETE", "OPTIONS")
+	templates.HandleFunc("/{id:[0-9]+}/instantiate", templateHandler.InstantiateTemplate).Methods("POST", "OPTIONS")
+
+	// Calendar feed token routes (perlu authentication)
+	protected.HandleFunc("/calendar/token", calendarHandler.GetToken).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/calendar/token", calendarHandler.RegenerateToken).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/calendar/token", calendarHandler.RevokeToken).Methods("DELETE", "OPTIONS")
+
+	// Project routes (perlu authentication)
+	projects := protected.PathPrefix("/projects").Subrouter()
+	projects.HandleFunc("", projectHandler.GetProjects).Methods("GET", "OPTIONS")
+	projects.HandleFunc("", projectHandler.CreateProject).Methods("POST", "OPTIONS")
+	projects.HandleFunc("/{id:[0-9]+}", projectHandler.GetProjectByID).Methods("GET", "OPTIONS")
+	projects.HandleFunc("/{id:[0-9]+}", projectHandler.UpdateProject).Methods("PUT", "OPTIONS")
+	projects.HandleFunc("/{id:[0-9]+}", projectHandler.DeleteProject).Methods("DELETE", "OPTIONS")
+
+	// Workflow routes (perlu authentication), perubahan hanya lewat admin routes
+	workflows := protected.PathPrefix("/workflows").Subrouter()
+	workflows.HandleFunc("", workflowHandler.GetWorkflows).Methods("GET", "OPTIONS")
+	workflows.HandleFunc("/{id:[0-9]+}", workflowHandler.GetWorkflowByID).Methods("GET", "OPTIONS")
 
 	// Admin routes (perlu authentication + admin role)
 	admin := api.PathPrefix("/admin").Subrouter()
 	admin.Use(middleware.AuthMiddleware(jwtManager))
 	admin.Use(middleware.AdminMiddleware())
 	admin.HandleFunc("/users", adminHandler.GetAllUsers).Methods("GET", "OPTIONS")
+	admin.HandleFunc("/workflows", workflowHandler.CreateWorkflow).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/workflows/{id:[0-9]+}", workflowHandler.UpdateWorkflow).Methods("PUT", "OPTIONS")
+	admin.HandleFunc("/workflows/{id:[0-9]+}", workflowHandler.DeleteWorkflow).Methods("DELETE", "OPTIONS")
 
 	return r
 }
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashPurger adalah bagian dari TaskService yang dibutuhkan scheduler
+type TrashPurger interface {
+	PurgeTrash(deletedBefore time.Time) (int, error)
+}
+
+// PurgeScheduler menghapus permanen task yang sudah terlalu lama di trash
+type PurgeScheduler struct {
+	purger    TrashPurger
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewPurgeScheduler membuat instance PurgeScheduler. Task yang berada di
+// trash lebih lama dari retention akan dihapus permanen.
+func NewPurgeScheduler(purger TrashPurger, retention, interval time.Duration) *PurgeScheduler {
+	return &PurgeScheduler{
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Start menjalankan scheduler di background sampai ctx dibatalkan
+func (s *PurgeScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if purged, err := s.RunOnce(time.Now()); err != nil {
+				log.Printf("Purge scheduler error: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d tasks from trash", purged)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce menghapus permanen task yang masuk trash sebelum now - retention
+// dan mengembalikan jumlah task yang dihapus
+func (s *PurgeScheduler) RunOnce(now time.Time) (int, error) {
+	return s.purger.PurgeTrash(now.Add(-s.retention))
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/notifier"
+)
+
+// ReminderTaskStore adalah bagian dari TaskRepository yang dibutuhkan scheduler
+type ReminderTaskStore interface {
+	GetDueForReminder(dueAfter, dueBefore time.Time) ([]model.Task, error)
+	MarkReminded(taskID int, remindedAt time.Time) error
+}
+
+// ReminderUserStore adalah bagian dari UserRepository yang dibutuhkan scheduler
+type ReminderUserStore interface {
+	GetByID(id int) (*model.User, error)
+}
+
+// ReminderScheduler mengirim reminder untuk task yang mendekati due date
+type ReminderScheduler struct {
+	taskStore ReminderTaskStore
+	userStore ReminderUserStore
+	notifier  notifier.Notifier
+	leadTime  time.Duration
+	interval  time.Duration
+}
+
+// NewReminderScheduler membuat instance ReminderScheduler. leadTime menentukan
+// berapa lama sebelum due date reminder dikirim.
+func NewReminderScheduler(taskStore ReminderTaskStore, userStore ReminderUserStore, n notifier.Notifier, leadTime, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		taskStore: taskStore,
+		userStore: userStore,
+		notifier:  n,
+		leadTime:  leadTime,
+		interval:  interval,
+	}
+}
+
+// Start menjalankan scheduler di background sampai ctx dibatalkan
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.RunOnce(time.Now()); err != nil {
+				log.Printf("Reminder scheduler error: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce mengirim reminder untuk semua task yang jatuh tempo dalam leadTime
+// dari now dan mengembalikan jumlah reminder yang terkirim
+func (s *ReminderScheduler) RunOnce(now time.Time) (int, error) {
+	tasks, err := s.taskStore.GetDueForReminder(now, now.Add(s.leadTime))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tasks due for reminder: %w", err)
+	}
+
+	sent := 0
+	for _, task := range tasks {
+		// Reminder dikirim ke assignee jika ada, selain itu ke pemilik task
+		recipientID := task.UserID
+		if task.AssigneeID != nil {
+			recipientID = *task.AssigneeID
+		}
+
+		user, err := s.userStore.GetByID(recipientID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get reminder recipient: %w", err)
+		}
+		if user == nil {
+			continue
+		}
+
+		err = s.notifier.Notify(notifier.Reminder{
+			TaskID:    task.ID,
+			UserID:    user.ID,
+			UserEmail: user.Email,
+			UserName:  user.Name,
+			Title:     task.Title,
+			DueAt:     *task.DueAt,
+		})
+		if err != nil {
+			// Task tidak ditandai agar dicoba lagi pada tick berikutnya
+			log.Printf("Failed to send reminder for task %d: %v", task.ID, err)
+			continue
+		}
+
+		if err := s.taskStore.MarkReminded(task.ID, now); err != nil {
+			return sent, fmt.Errorf("failed to mark task reminded: %w", err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
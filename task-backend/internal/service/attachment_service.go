@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+	"github.com/Mahathirrr/task-management-backend/pkg/storage"
+)
+
+// sniffLen adalah jumlah byte yang dibaca http.DetectContentType
+const sniffLen = 512
+
+type AttachmentService interface {
+	UploadAttachment(taskID, userID int, fileName string, content io.Reader, isAdmin bool) (*model.Attachment, error)
+	GetTaskAttachments(taskID, userID int, isAdmin bool) ([]model.Attachment, error)
+	OpenAttachment(taskID, attachmentID, userID int, isAdmin bool) (*model.Attachment, io.ReadCloser, error)
+	DeleteAttachment(taskID, attachmentID, userID int, isAdmin bool) error
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	taskService    TaskService
+	fileStorage    storage.Storage
+	maxSize        int64
+	allowedTypes   map[string]bool
+}
+
+// NewAttachmentService membuat instance AttachmentService. Akses ke attachment
+// mengikuti authorization task melalui TaskService.GetTaskByID.
+func NewAttachmentService(attachmentRepo repository.AttachmentRepository, taskService TaskService, fileStorage storage.Storage, maxSize int64, allowedTypes []string) AttachmentService {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, contentType := range allowedTypes {
+		allowed[strings.ToLower(contentType)] = true
+	}
+
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		taskService:    taskService,
+		fileStorage:    fileStorage,
+		maxSize:        maxSize,
+		allowedTypes:   allowed,
+	}
+}
+
+// UploadAttachment menyimpan file ke storage lalu mencatat metadata-nya.
+// Content type ditentukan dari isi file, bukan dari header yang dikirim client.
+func (s *attachmentService) UploadAttachment(taskID, userID int, fileName string, content io.Reader, isAdmin bool) (*model.Attachment, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !s.allowedTypes[contentType] {
+		return nil, errors.New(model.ErrAttachmentTypeNotAllowed)
+	}
+
+	key, err := newStorageKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Baca satu byte lebih dari batas agar file yang terlalu besar bisa dikenali
+	body := io.MultiReader(bytes.NewReader(head), io.LimitReader(content, s.maxSize+1-int64(n)))
+	size, err := s.fileStorage.Save(key, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+	if size > s.maxSize {
+		s.deleteFile(key)
+		return nil, errors.New(model.ErrAttachmentTooLarge)
+	}
+
+	attachment := &model.Attachment{
+		TaskID:      taskID,
+		UserID:      userID,
+		FileName:    sanitizeFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		s.deleteFile(key)
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	createdAttachment, err := s.attachmentRepo.GetByID(attachment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created attachment: %w", err)
+	}
+
+	return createdAttachment, nil
+}
+
+// GetTaskAttachments mengambil semua attachment pada task
+func (s *attachmentService) GetTaskAttachments(taskID, userID int, isAdmin bool) ([]model.Attachment, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	if attachments == nil {
+		attachments = []model.Attachment{}
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment membuka isi attachment untuk di-stream ke client. Caller wajib menutup reader.
+func (s *attachmentService) OpenAttachment(taskID, attachmentID, userID int, isAdmin bool) (*model.Attachment, io.ReadCloser, error) {
+	attachment, _, err := s.getTaskAttachment(taskID, attachmentID, userID, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.fileStorage.Open(attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, errors.New(model.ErrAttachmentNotFound)
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment menghapus attachment. Boleh dilakukan oleh uploader, pemilik task, atau admin.
+func (s *attachmentService) DeleteAttachment(taskID, attachmentID, userID int, isAdmin bool) error {
+	attachment, task, err := s.getTaskAttachment(taskID, attachmentID, userID, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	if attachment.UserID != userID && !canManageTask(task, userID, isAdmin) {
+		return errors.New(model.ErrForbidden)
+	}
+
+	if err := s.attachmentRepo.Delete(attachmentID); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	s.deleteFile(attachment.StorageKey)
+	return nil
+}
+
+// getTaskAttachment mengambil attachment pada task setelah memastikan user boleh mengakses task
+func (s *attachmentService) getTaskAttachment(taskID, attachmentID, userID int, isAdmin bool) (*model.Attachment, *model.Task, error) {
+	task, err := s.taskService.GetTaskByID(taskID, userID, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetByID(attachmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	if attachment == nil || attachment.TaskID != taskID {
+		return nil, nil, errors.New(model.ErrAttachmentNotFound)
+	}
+
+	return attachment, task, nil
+}
+
+// deleteFile menghapus file dari storage. Kegagalan hanya di-log karena
+// metadata sudah tidak menunjuk ke file tersebut.
+func (s *attachmentService) deleteFile(key string) {
+	if err := s.fileStorage.Delete(key); err != nil {
+		log.Printf("Failed to delete attachment file %s: %v", key, err)
+	}
+}
+
+// newStorageKey membuat key acak untuk file attachment pada task
+func newStorageKey(taskID int) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(buf)), nil
+}
+
+// sanitizeFileName mengambil nama file tanpa path dan membatasi panjangnya
+func sanitizeFileName(fileName string) string {
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+
+	runes := []rune(name)
+	if len(runes) > 255 {
+		name = string(runes[:255])
+	}
+
+	return name
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type CalendarService interface {
+	GetToken(userID int) (*model.CalendarToken, error)
+	RegenerateToken(userID int) (string, *model.CalendarToken, error)
+	RevokeToken(userID int) error
+	GetFeedUser(token string) (*model.User, error)
+	GetFeedTasks(userID int, fn func(task *model.Task) error) error
+}
+
+type calendarService struct {
+	calendarTokenRepo repository.CalendarTokenRepository
+	userRepo          repository.UserRepository
+	taskService       TaskService
+}
+
+func NewCalendarService(calendarTokenRepo repository.CalendarTokenRepository, userRepo repository.UserRepository, taskService TaskService) CalendarService {
+	return &calendarService{
+		calendarTokenRepo: calendarTokenRepo,
+		userRepo:          userRepo,
+		taskService:       taskService,
+	}
+}
+
+// GetToken mengambil status token feed kalender milik user
+func (s *calendarService) GetToken(userID int) (*model.CalendarToken, error) {
+	token, err := s.calendarTokenRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	if token == nil {
+		return nil, errors.New(model.ErrCalendarTokenNotFound)
+	}
+
+	return token, nil
+}
+
+// RegenerateToken membuat token feed baru dan mengembalikan token asli.
+// URL dengan token lama langsung tidak berlaku.
+func (s *calendarService) RegenerateToken(userID int) (string, *model.CalendarToken, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	err := s.calendarTokenRepo.Save(&model.CalendarToken{UserID: userID, TokenHash: hashCalendarToken(token)})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to save calendar token: %w", err)
+	}
+
+	saved, err := s.GetToken(userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, saved, nil
+}
+
+// RevokeToken mencabut token feed sehingga URL feed tidak bisa dipakai lagi
+func (s *calendarService) RevokeToken(userID int) error {
+	if _, err := s.GetToken(userID); err != nil {
+		return err
+	}
+
+	if err := s.calendarTokenRepo.Delete(userID); err != nil {
+		return fmt.Errorf("failed to revoke calendar token: %w", err)
+	}
+
+	return nil
+}
+
+// GetFeedUser mencari pemilik token feed. Token yang tidak dikenal atau
+// sudah dicabut dianggap tidak ada.
+func (s *calendarService) GetFeedUser(token string) (*model.User, error) {
+	calendarToken, err := s.calendarTokenRepo.GetByHash(hashCalendarToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	if calendarToken == nil {
+		return nil, errors.New(model.ErrCalendarTokenNotFound)
+	}
+
+	user, err := s.userRepo.GetByID(calendarToken.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.New(model.ErrCalendarTokenNotFound)
+	}
+
+	return user, nil
+}
+
+// GetFeedTasks memanggil fn untuk setiap task milik atau yang di-assign ke
+// user dengan due date dalam rentang feed, dibaca per batch seperti export
+func (s *calendarService) GetFeedTasks(userID int, fn func(task *model.Task) error) error {
+	dueAfter := time.Now().Add(-model.CalendarFeedLookback)
+	return s.taskService.ExportTasks(userID, model.TaskFilter{DueAfter: &dueAfter}, false, fn)
+}
+
+// hashCalendarToken menghitung hash token yang disimpan di database
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type CommentService interface {
+	CreateComment(taskID, userID int, req *model.CommentCreateRequest, isAdmin bool) (*model.Comment, error)
+	GetTaskComments(taskID, userID int, page, limit int, isAdmin bool) (*model.CommentsResponse, error)
+	UpdateComment(taskID, commentID, userID int, req *model.CommentUpdateRequest, isAdmin bool) (*model.Comment, error)
+	DeleteComment(taskID, commentID, userID int, isAdmin bool) error
+}
+
+type commentService struct {
+	commentRepo repository.CommentRepository
+	taskService TaskService
+}
+
+// NewCommentService membuat instance CommentService. Akses ke komentar
+// mengikuti authorization task melalui TaskService.GetTaskByID.
+func NewCommentService(commentRepo repository.CommentRepository, taskService TaskService) CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		taskService: taskService,
+	}
+}
+
+// CreateComment membuat komentar atau reply pada task
+func (s *commentService) CreateComment(taskID, userID int, req *model.CommentCreateRequest, isAdmin bool) (*model.Comment, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{
+		TaskID: taskID,
+		UserID: userID,
+		Body:   req.Body,
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if parent == nil || parent.TaskID != taskID {
+			return nil, errors.New(model.ErrInvalidParentComment)
+		}
+
+		// Reply ke reply ditempelkan ke komentar root agar thread tetap satu level
+		rootID := parent.ID
+		if parent.ParentID != nil {
+			rootID = *parent.ParentID
+		}
+		comment.ParentID = &rootID
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	createdComment, err := s.commentRepo.GetByID(comment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created comment: %w", err)
+	}
+
+	return createdComment, nil
+}
+
+// GetTaskComments mengambil komentar pada task dengan pagination
+func (s *commentService) GetTaskComments(taskID, userID int, page, limit int, isAdmin bool) (*model.CommentsResponse, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	comments, total, err := s.commentRepo.GetByTaskID(taskID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	if comments == nil {
+		comments = []model.Comment{}
+	}
+
+	return &model.CommentsResponse{
+		Comments: comments,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+// UpdateComment mengupdate komentar milik sendiri
+func (s *commentService) UpdateComment(taskID, commentID, userID int, req *model.CommentUpdateRequest, isAdmin bool) (*model.Comment, error) {
+	comment, err := s.getTaskComment(taskID, commentID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admin pun tidak boleh mengubah isi komentar user lain
+	if comment.UserID != userID {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	comment.Body = req.Body
+	if err := s.commentRepo.Update(comment); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	updatedComment, err := s.commentRepo.GetByID(commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated comment: %w", err)
+	}
+
+	return updatedComment, nil
+}
+
+// DeleteComment menghapus komentar milik sendiri, admin boleh menghapus komentar apa pun
+func (s *commentService) DeleteComment(taskID, commentID, userID int, isAdmin bool) error {
+	comment, err := s.getTaskComment(taskID, commentID, userID, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !isAdmin && comment.UserID != userID {
+		return errors.New(model.ErrForbidden)
+	}
+
+	if err := s.commentRepo.Delete(commentID); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// getTaskComment mengambil komentar pada task setelah memastikan user boleh mengakses task
+func (s *commentService) getTaskComment(taskID, commentID, userID int, isAdmin bool) (*model.Comment, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil || comment.TaskID != taskID {
+		return nil, errors.New(model.ErrCommentNotFound)
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type LabelService interface {
+	CreateLabel(userID int, req *model.LabelCreateRequest) (*model.Label, error)
+	GetUserLabels(userID int) ([]model.Label, error)
+	UpdateLabel(labelID, userID int, req *model.LabelUpdateRequest) (*model.Label, error)
+	DeleteLabel(labelID, userID int) error
+}
+
+type labelService struct {
+	labelRepo repository.LabelRepository
+}
+
+func NewLabelService(labelRepo repository.LabelRepository) LabelService {
+	return &labelService{
+		labelRepo: labelRepo,
+	}
+}
+
+// CreateLabel membuat label baru milik user
+func (s *labelService) CreateLabel(userID int, req *model.LabelCreateRequest) (*model.Label, error) {
+	existing, err := s.labelRepo.GetByName(userID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing label: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.New(model.ErrLabelAlreadyExists)
+	}
+
+	label := &model.Label{
+		UserID: userID,
+		Name:   req.Name,
+		Color:  req.Color,
+	}
+
+	if err := s.labelRepo.Create(label); err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	createdLabel, err := s.labelRepo.GetByID(label.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created label: %w", err)
+	}
+
+	return createdLabel, nil
+}
+
+// GetUserLabels mengambil semua labels milik user
+func (s *labelService) GetUserLabels(userID int) ([]model.Label, error) {
+	labels, err := s.labelRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	if labels == nil {
+		labels = []model.Label{}
+	}
+
+	return labels, nil
+}
+
+// UpdateLabel mengupdate label milik user
+func (s *labelService) UpdateLabel(labelID, userID int, req *model.LabelUpdateRequest) (*model.Label, error) {
+	label, err := s.getOwnedLabel(labelID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil && *req.Name != label.Name {
+		existing, err := s.labelRepo.GetByName(userID, *req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing label: %w", err)
+		}
+		if existing != nil {
+			return nil, errors.New(model.ErrLabelAlreadyExists)
+		}
+		label.Name = *req.Name
+	}
+	if req.Color != nil {
+		label.Color = *req.Color
+	}
+
+	if err := s.labelRepo.Update(label); err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	updatedLabel, err := s.labelRepo.GetByID(labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated label: %w", err)
+	}
+
+	return updatedLabel, nil
+}
+
+// DeleteLabel menghapus label milik user beserta relasinya ke tasks
+func (s *labelService) DeleteLabel(labelID, userID int) error {
+	if _, err := s.getOwnedLabel(labelID, userID); err != nil {
+		return err
+	}
+
+	if err := s.labelRepo.Delete(labelID); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+
+	return nil
+}
+
+// getOwnedLabel mengambil label dan memastikan label milik user
+func (s *labelService) getOwnedLabel(labelID, userID int) (*model.Label, error) {
+	label, err := s.labelRepo.GetByID(labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+	if label == nil {
+		return nil, errors.New(model.ErrLabelNotFound)
+	}
+	if label.UserID != userID {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	return label, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type ProjectService interface {
+	CreateProject(userID int, req *model.ProjectCreateRequest) (*model.Project, error)
+	GetProjectByID(projectID, userID int, isAdmin bool) (*model.Project, error)
+	GetUserProjects(userID int, includeArchived bool) ([]model.Project, error)
+	UpdateProject(projectID, userID int, req *model.ProjectUpdateRequest, isAdmin bool) (*model.Project, error)
+	DeleteProject(projectID, userID int, isAdmin bool) error
+}
+
+type projectService struct {
+	projectRepo  repository.ProjectRepository
+	workflowRepo repository.WorkflowRepository
+}
+
+func NewProjectService(projectRepo repository.ProjectRepository, workflowRepo repository.WorkflowRepository) ProjectService {
+	return &projectService{
+		projectRepo:  projectRepo,
+		workflowRepo: workflowRepo,
+	}
+}
+
+// CreateProject membuat project baru milik user
+func (s *projectService) CreateProject(userID int, req *model.ProjectCreateRequest) (*model.Project, error) {
+	project := &model.Project{
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+		WorkflowID:  req.WorkflowID,
+	}
+
+	if err := s.checkWorkflowExists(project.WorkflowID); err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	createdProject, err := s.projectRepo.GetByID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created project: %w", err)
+	}
+
+	return createdProject, nil
+}
+
+// GetProjectByID mengambil project berdasarkan ID dengan authorization check
+func (s *projectService) GetProjectByID(projectID, userID int, isAdmin bool) (*model.Project, error) {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil {
+		return nil, errors.New(model.ErrProjectNotFound)
+	}
+
+	if !isAdmin && project.UserID != userID {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	return project, nil
+}
+
+// GetUserProjects mengambil projects milik user
+func (s *projectService) GetUserProjects(userID int, includeArchived bool) ([]model.Project, error) {
+	projects, err := s.projectRepo.GetByUserID(userID, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	if projects == nil {
+		projects = []model.Project{}
+	}
+
+	return projects, nil
+}
+
+// UpdateProject mengupdate project, termasuk mengarsipkan atau membuka arsip
+func (s *projectService) UpdateProject(projectID, userID int, req *model.ProjectUpdateRequest, isAdmin bool) (*model.Project, error) {
+	project, err := s.GetProjectByID(projectID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.Description != nil {
+		project.Description = req.Description
+	}
+	if req.Archived != nil {
+		project.Archived = *req.Archived
+	}
+	if req.WorkflowID != nil {
+		project.WorkflowID = req.WorkflowID
+		if *req.WorkflowID == 0 {
+			project.WorkflowID = nil
+		}
+		if err := s.checkWorkflowExists(project.WorkflowID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.projectRepo.Update(project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	updatedProject, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated project: %w", err)
+	}
+
+	return updatedProject, nil
+}
+
+// DeleteProject menghapus project tanpa menghapus tasks di dalamnya
+func (s *projectService) DeleteProject(projectID, userID int, isAdmin bool) error {
+	if _, err := s.GetProjectByID(projectID, userID, isAdmin); err != nil {
+		return err
+	}
+
+	if err := s.projectRepo.Delete(projectID); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return nil
+}
+
+// checkWorkflowExists memastikan workflow yang dipilih project ada
+func (s *projectService) checkWorkflowExists(workflowID *int) error {
+	if workflowID == nil {
+		return nil
+	}
+
+	workflow, err := s.workflowRepo.GetByID(*workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+	if workflow == nil {
+		return errors.New(model.ErrWorkflowNotFound)
+	}
+
+	return nil
+}
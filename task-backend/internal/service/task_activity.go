@@ -0,0 +1,98 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+// newTaskActivity membuat entri activity untuk actor pada task
+func newTaskActivity(taskID, actorID int, action model.ActivityAction) model.TaskActivity {
+	return model.TaskActivity{
+		TaskID: taskID,
+		UserID: &actorID,
+		Action: action,
+	}
+}
+
+// taskChanges membandingkan task sebelum dan sesudah update dan menghasilkan
+// satu entri activity per field yang berubah
+func taskChanges(before, after *model.Task, actorID int) []model.TaskActivity {
+	fields := []struct {
+		name     string
+		old, new *string
+	}{
+		{"title", stringValue(before.Title), stringValue(after.Title)},
+		{"description", before.Description, after.Description},
+		{"status", stringValue(string(before.Status)), stringValue(string(after.Status))},
+		{"priority", stringValue(string(before.Priority)), stringValue(string(after.Priority))},
+		{"start_at", timeValue(before.StartAt), timeValue(after.StartAt)},
+		{"due_at", timeValue(before.DueAt), timeValue(after.DueAt)},
+		{"project_id", intValue(before.ProjectID), intValue(after.ProjectID)},
+		{"assignee_id", intValue(before.AssigneeID), intValue(after.AssigneeID)},
+		{"labels", labelsValue(before.Labels), labelsValue(after.Labels)},
+		{"recurrence_rule", before.RecurrenceRule, after.RecurrenceRule},
+	}
+
+	var activities []model.TaskActivity
+	for _, field := range fields {
+		if sameValue(field.old, field.new) {
+			continue
+		}
+
+		activity := newTaskActivity(after.ID, actorID, model.ActivityUpdated)
+		activity.Field = stringValue(field.name)
+		activity.OldValue = field.old
+		activity.NewValue = field.new
+		activities = append(activities, activity)
+	}
+
+	return activities
+}
+
+func stringValue(s string) *string {
+	return &s
+}
+
+func timeValue(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	return stringValue(t.UTC().Format(time.RFC3339))
+}
+
+func intValue(i *int) *string {
+	if i == nil {
+		return nil
+	}
+	return stringValue(strconv.Itoa(*i))
+}
+
+// labelsValue menuliskan label sebagai daftar ID terurut, misalnya "1,4,7"
+func labelsValue(labels []model.Label) *string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+	}
+	sort.Ints(ids)
+
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return stringValue(strings.Join(parts, ","))
+}
+
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
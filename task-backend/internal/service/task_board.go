@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+// MoveTask memindahkan task ke status dan posisi baru di board. Seperti
+// mengubah status, assignee juga boleh memindahkan task. Perubahan status
+// divalidasi dengan aturan yang sama dengan update biasa.
+func (s *taskService) MoveTask(taskID, userID int, req *model.TaskMoveRequest, isAdmin bool) (*model.Task, error) {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+	if !canReadTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	if !task.MatchesVersion(req.IfMatch) {
+		return nil, errors.New(model.ErrTaskModified)
+	}
+
+	for _, neighbourID := range []*int{req.BeforeID, req.AfterID} {
+		if neighbourID == nil {
+			continue
+		}
+		if *neighbourID == taskID {
+			return nil, errors.New(model.ErrInvalidMove)
+		}
+		neighbour, err := s.taskRepo.GetByID(*neighbourID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get neighbour task: %w", err)
+		}
+		if neighbour == nil || !canReadTask(neighbour, userID, isAdmin) {
+			return nil, errors.New(model.ErrMoveNeighbourNotFound)
+		}
+	}
+
+	before := *task
+	if req.Status != "" {
+		task.Status = req.Status
+	}
+	if task.Status != before.Status {
+		if err := s.checkStatusTransition(task.ProjectID, before.Status, task.Status); err != nil {
+			return nil, err
+		}
+		if err := s.checkBlockersCompleted(&before, task, isAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	// Perpindahan dan riwayatnya disimpan dalam satu transaksi
+	var movedTask *model.Task
+	err = s.withTx(func(tx *taskService) error {
+		// Posisi tetangga dicek ulang di dalam transaksi karena board bisa
+		// berubah oleh request lain sejak dibaca client
+		err := tx.taskRepo.Move(&model.TaskMove{
+			TaskID:   taskID,
+			Version:  task.Version,
+			Status:   task.Status,
+			BeforeID: req.BeforeID,
+			AfterID:  req.AfterID,
+		})
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return errors.New(model.ErrTaskModified)
+		}
+		if errors.Is(err, repository.ErrRankConflict) {
+			return errors.New(model.ErrBoardConflict)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+
+		movedTask, err = tx.recordTaskChanges(&before, taskID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movedTask, nil
+}
+
+// GetBoard mengambil task yang dikelompokkan per status urut berdasarkan
+// rank. Kolom mengikuti status workflow project pada filter (atau workflow
+// default), ditambah status lain yang masih dipakai task agar tidak ada
+// task yang hilang dari board. Admin melihat task semua user.
+func (s *taskService) GetBoard(userID int, filter model.TaskFilter, limit int, isAdmin bool) (*model.BoardResponse, error) {
+	var owner *int
+	if !isAdmin {
+		owner = &userID
+	}
+
+	workflow, err := s.workflowFor(filter.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []model.BoardColumn
+	for _, status := range workflow.Statuses {
+		columns = append(columns, model.BoardColumn{Status: status.Key, Name: status.Name})
+	}
+
+	statuses, err := s.taskRepo.GetBoardStatuses(owner, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board statuses: %w", err)
+	}
+	for _, status := range statuses {
+		if !workflow.HasStatus(status) {
+			columns = append(columns, model.BoardColumn{Status: status, Name: string(status)})
+		}
+	}
+
+	for i := range columns {
+		tasks, hasMore, err := s.taskRepo.GetBoardColumn(owner, columns[i].Status, filter, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get board column: %w", err)
+		}
+		if tasks == nil {
+			tasks = []model.Task{}
+		}
+		columns[i].Tasks = tasks
+		columns[i].HasMore = hasMore
+	}
+
+	return &model.BoardResponse{Columns: columns}, nil
+}
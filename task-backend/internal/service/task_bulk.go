@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+// BulkUpdateTasks menerapkan satu operasi ke banyak task. Authorization dan
+// validasi dicek per task dengan aturan yang sama seperti operasi tunggal;
+// task yang lolos diubah bersama dalam satu transaksi, sisanya dilaporkan
+// pada hasil per item. Pengecekan, perubahan, activity, dan occurrence
+// berikutnya berjalan dalam transaksi yang sama.
+func (s *taskService) BulkUpdateTasks(userID int, req *model.TaskBulkRequest, isAdmin bool) (*model.TaskBulkResponse, error) {
+	var resp *model.TaskBulkResponse
+	err := s.withTx(func(tx *taskService) error {
+		var err error
+		resp, err = tx.bulkUpdateTasks(userID, req, isAdmin)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// bulkUpdateTasks adalah isi BulkUpdateTasks yang berjalan di dalam transaksi
+func (s *taskService) bulkUpdateTasks(userID int, req *model.TaskBulkRequest, isAdmin bool) (*model.TaskBulkResponse, error) {
+	resp := &model.TaskBulkResponse{
+		Operation: req.Operation,
+		Results:   []model.BulkItemResult{},
+	}
+
+	change := &model.TaskBulkChange{
+		Operation: req.Operation,
+		DeletedAt: time.Now().Truncate(time.Second),
+	}
+	switch req.Operation {
+	case model.BulkUpdateStatus:
+		change.Status = *req.Status
+	case model.BulkMoveProject:
+		if *req.ProjectID != 0 {
+			change.ProjectID = req.ProjectID
+		}
+	case model.BulkAddLabel:
+		change.LabelID = *req.LabelID
+	}
+
+	// Task dikunci sebelum dibaca sehingga authorization dan validasi
+	// berlaku untuk state yang sama dengan yang diubah ApplyBulk
+	taskIDs := make([]int, 0, len(req.TaskIDs))
+	seen := make(map[int]bool, len(req.TaskIDs))
+	for _, taskID := range req.TaskIDs {
+		if !seen[taskID] {
+			seen[taskID] = true
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	if err := s.taskRepo.LockTasks(taskIDs); err != nil {
+		return nil, err
+	}
+
+	// Salinan state awal dan akhir setiap task untuk riwayat perubahan
+	var befores, afters []model.Task
+	var deletedIDs []int
+
+	for _, taskID := range taskIDs {
+		task, err := s.taskRepo.GetByID(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+
+		after, err := s.bulkApply(task, userID, req, change, isAdmin)
+		if err != nil {
+			result, ok := bulkErrorResult(taskID, err)
+			if !ok {
+				return nil, err
+			}
+			resp.Results = append(resp.Results, result)
+			resp.Failed++
+			continue
+		}
+
+		change.TaskIDs = append(change.TaskIDs, taskID)
+		befores = append(befores, *task)
+		afters = append(afters, *after)
+		resp.Results = append(resp.Results, model.BulkItemResult{TaskID: taskID, Status: model.BulkResultOK})
+		resp.Succeeded++
+	}
+
+	if req.Operation == model.BulkDelete {
+		deletedIDs = append(deletedIDs, change.TaskIDs...)
+		if req.Subtasks == model.SubtaskDeleteCascade {
+			for _, taskID := range change.TaskIDs {
+				subtaskIDs, err := s.descendantIDs(taskID)
+				if err != nil {
+					return nil, err
+				}
+				for _, subtaskID := range subtaskIDs {
+					if !seen[subtaskID] {
+						seen[subtaskID] = true
+						deletedIDs = append(deletedIDs, subtaskID)
+					}
+				}
+			}
+		} else {
+			change.ReparentIDs = change.TaskIDs
+		}
+		change.TaskIDs = deletedIDs
+	}
+
+	if err := s.taskRepo.ApplyBulk(change); err != nil {
+		return nil, fmt.Errorf("failed to apply bulk operation: %w", err)
+	}
+
+	var activities []model.TaskActivity
+	if req.Operation == model.BulkDelete {
+		for _, deletedID := range deletedIDs {
+			activities = append(activities, newTaskActivity(deletedID, userID, model.ActivityDeleted))
+		}
+	} else {
+		for i := range befores {
+			activities = append(activities, taskChanges(&befores[i], &afters[i], userID)...)
+		}
+	}
+	if err := s.activityRepo.Create(activities); err != nil {
+		return nil, fmt.Errorf("failed to record task activity: %w", err)
+	}
+
+	// Menyelesaikan occurrence task berulang membuat occurrence berikutnya
+	if req.Operation == model.BulkUpdateStatus {
+		for i := range afters {
+			done, err := s.becameDone(&befores[i], &afters[i])
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				if err := s.ensureNextOccurrence(&afters[i], userID); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// bulkApply mengecek authorization dan validasi bulk operation untuk satu
+// task, lalu mengembalikan state task setelah operasi diterapkan
+func (s *taskService) bulkApply(task *model.Task, userID int, req *model.TaskBulkRequest, change *model.TaskBulkChange, isAdmin bool) (*model.Task, error) {
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+
+	// Assignee hanya boleh mengubah status
+	if !canReadTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+	if req.Operation != model.BulkUpdateStatus && !canManageTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	after := *task
+	switch req.Operation {
+	case model.BulkUpdateStatus:
+		after.Status = change.Status
+		if after.Status != task.Status {
+			if err := s.checkStatusTransition(task.ProjectID, task.Status, after.Status); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkBlockersCompleted(task, &after, isAdmin); err != nil {
+			return nil, err
+		}
+
+	case model.BulkMoveProject:
+		after.ProjectID = change.ProjectID
+		if err := s.checkProjectOwnedBy(task.UserID, after.ProjectID); err != nil {
+			return nil, err
+		}
+		// Status task harus ada di workflow project tujuan
+		if err := s.checkStatusTransition(after.ProjectID, task.Status, task.Status); err != nil {
+			return nil, err
+		}
+
+	case model.BulkAddLabel:
+		if err := checkLabelsOwnedBy(s.labelRepo, task.UserID, []int{change.LabelID}); err != nil {
+			return nil, err
+		}
+		after.Labels = task.Labels
+		if !hasLabel(task.Labels, change.LabelID) {
+			after.Labels = append(append([]model.Label{}, task.Labels...), model.Label{ID: change.LabelID})
+		}
+	}
+
+	return &after, nil
+}
+
+// bulkErrorResult memetakan error per task ke hasil bulk operation. ok false
+// berarti error bukan kesalahan per task dan seluruh operasi dibatalkan.
+func bulkErrorResult(taskID int, err error) (model.BulkItemResult, bool) {
+	result := model.BulkItemResult{TaskID: taskID, Error: err.Error()}
+
+	switch err.Error() {
+	case model.ErrTaskNotFound:
+		result.Status = model.BulkResultNotFound
+	case model.ErrForbidden:
+		result.Status = model.BulkResultForbidden
+	case model.ErrInvalidStatus, model.ErrInvalidTransition, model.ErrInvalidProject, model.ErrInvalidLabels, model.ErrTaskBlocked:
+		result.Status = model.BulkResultInvalid
+	default:
+		return result, false
+	}
+
+	return result, true
+}
+
+// hasLabel mengecek apakah label sudah terpasang
+func hasLabel(labels []model.Label, labelID int) bool {
+	for _, label := range labels {
+		if label.ID == labelID {
+			return true
+		}
+	}
+	return false
+}
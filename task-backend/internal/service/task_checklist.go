@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/markdown"
+)
+
+// ToggleChecklistItem mencentang atau menghapus centang checkbox ke-index
+// (urutan checkbox pada description_html, mulai dari 0) dengan menulis ulang
+// description. Seperti status, checklist boleh diubah oleh assignee.
+func (s *taskService) ToggleChecklistItem(taskID, index, userID int, req *model.TaskChecklistRequest, isAdmin bool) (*model.Task, error) {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+	if !canReadTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	if !task.MatchesVersion(req.IfMatch) {
+		return nil, errors.New(model.ErrTaskModified)
+	}
+
+	var description string
+	if task.Description != nil {
+		description = *task.Description
+	}
+	updated, err := markdown.ToggleTask(description, index, *req.Checked)
+	if errors.Is(err, markdown.ErrTaskItemNotFound) {
+		return nil, errors.New(model.ErrChecklistItemNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to toggle checklist item: %w", err)
+	}
+
+	// Checkbox sudah dalam state yang diminta
+	if updated == description {
+		return task, nil
+	}
+
+	before := *task
+	task.Description = &updated
+	return s.saveTaskChanges(&before, task, nil, userID)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+// AddDependency menandai task terblokir oleh blocker. Hanya pemilik task
+// atau admin yang boleh menambah blocker, dan blocker harus bisa dilihat
+// oleh user tersebut.
+func (s *taskService) AddDependency(taskID, blockerID, userID int, isAdmin bool) (*model.Task, error) {
+	if blockerID == taskID {
+		return nil, errors.New(model.ErrDependencySelf)
+	}
+
+	var updatedTask *model.Task
+	err := s.withTx(func(tx *taskService) error {
+		var err error
+		updatedTask, err = tx.addDependency(taskID, blockerID, userID, isAdmin)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+// addDependency menjalankan AddDependency di dalam transaksi. Kedua task
+// dikunci lebih dulu agar dependency A→B dan B→A yang ditambahkan bersamaan
+// tidak lolos cycle check dan membentuk cycle.
+func (s *taskService) addDependency(taskID, blockerID, userID int, isAdmin bool) (*model.Task, error) {
+	if err := s.taskRepo.LockTasks([]int{taskID, blockerID}); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+	if !canManageTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	blocker, err := s.taskRepo.GetByID(blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocker task: %w", err)
+	}
+	if blocker == nil || !canReadTask(blocker, userID, isAdmin) {
+		return nil, errors.New(model.ErrBlockerNotFound)
+	}
+
+	// Blocker yang (tidak langsung) menunggu task ini akan membentuk cycle
+	blocked, err := s.isBlockedBy(blockerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New(model.ErrDependencyCycle)
+	}
+
+	if err := s.taskRepo.AddDependency(taskID, blockerID); err != nil {
+		return nil, fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	updatedTask, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated task: %w", err)
+	}
+
+	return updatedTask, nil
+}
+
+// RemoveDependency menghapus blocker dari task
+func (s *taskService) RemoveDependency(taskID, blockerID, userID int, isAdmin bool) error {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return errors.New(model.ErrTaskNotFound)
+	}
+	if !canManageTask(task, userID, isAdmin) {
+		return errors.New(model.ErrForbidden)
+	}
+
+	blockers, err := s.taskRepo.GetBlockers([]int{taskID})
+	if err != nil {
+		return fmt.Errorf("failed to get blockers: %w", err)
+	}
+	if !containsID(blockers[taskID], blockerID) {
+		return errors.New(model.ErrDependencyNotFound)
+	}
+
+	if err := s.taskRepo.RemoveDependency(taskID, blockerID); err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	return nil
+}
+
+// isBlockedBy mengecek apakah taskID terblokir oleh targetID, langsung
+// maupun melalui blocker lain. Graph ditelusuri per level dengan satu query
+// untuk setiap level.
+func (s *taskService) isBlockedBy(taskID, targetID int) (bool, error) {
+	visited := map[int]bool{taskID: true}
+	level := []int{taskID}
+
+	for len(level) > 0 {
+		blockers, err := s.taskRepo.GetBlockers(level)
+		if err != nil {
+			return false, fmt.Errorf("failed to get blockers: %w", err)
+		}
+
+		var next []int
+		for _, id := range level {
+			for _, blockerID := range blockers[id] {
+				if blockerID == targetID {
+					return true, nil
+				}
+				if !visited[blockerID] {
+					visited[blockerID] = true
+					next = append(next, blockerID)
+				}
+			}
+		}
+		level = next
+	}
+
+	return false, nil
+}
+
+// checkBlockersCompleted memastikan task yang akan di-complete tidak punya
+// blocker yang belum selesai. Admin boleh melewati pengecekan ini.
+func (s *taskService) checkBlockersCompleted(before, task *model.Task, isAdmin bool) error {
+	if isAdmin {
+		return nil
+	}
+	done, err := s.becameDone(before, task)
+	if err != nil || !done {
+		return err
+	}
+
+	blockerIDs, err := s.taskRepo.GetIncompleteBlockers(task.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get incomplete blockers: %w", err)
+	}
+	if len(blockerIDs) > 0 {
+		return errors.New(model.ErrTaskBlocked)
+	}
+
+	return nil
+}
+
+// containsID mengecek apakah id ada di ids
+func containsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+	"github.com/Mahathirrr/task-management-backend/pkg/recurrence"
+)
+
+// UpdateTaskSeries mengupdate occurrence ini beserta semua occurrence
+// sesudahnya. Title, description, priority, project, assignee, dan labels
+// disalin ke occurrence berikutnya yang belum selesai. Jika recurrence_rule
+// berubah, series lama diakhiri sebelum occurrence ini dan occurrence ini
+// memulai series baru; occurrence berikutnya yang belum selesai dipindahkan
+// ke trash dan akan dibuat ulang mengikuti rule baru. Semua perubahan
+// berjalan dalam satu transaksi.
+func (s *taskService) UpdateTaskSeries(taskID, userID int, req *model.TaskUpdateRequest, isAdmin bool) (*model.Task, error) {
+	var updatedTask *model.Task
+	err := s.withTx(func(tx *taskService) error {
+		var err error
+		updatedTask, err = tx.updateTaskSeries(taskID, userID, req, isAdmin)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+func (s *taskService) updateTaskSeries(taskID, userID int, req *model.TaskUpdateRequest, isAdmin bool) (*model.Task, error) {
+	task, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+	if task.SeriesID == nil || task.OccurrenceAt == nil {
+		return s.UpdateTask(taskID, userID, req, isAdmin)
+	}
+	if !canManageTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	var newRule string
+	if req.RecurrenceRule != nil && *req.RecurrenceRule != "" {
+		if newRule, err = normalizeRule(*req.RecurrenceRule); err != nil {
+			return nil, err
+		}
+		// Due date dicek sebelum ada perubahan yang disimpan
+		dueAt := task.DueAt
+		if req.DueAt != nil {
+			dueAt = req.DueAt
+		}
+		if dueAt == nil {
+			return nil, errors.New(model.ErrRecurrenceRequiresDueDate)
+		}
+	}
+
+	seriesID, occurrenceAt := *task.SeriesID, *task.OccurrenceAt
+	occurrences, err := s.taskRepo.GetSeriesOccurrences(seriesID, occurrenceAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	future, err := s.openOccurrences(occurrences)
+	if err != nil {
+		return nil, err
+	}
+
+	thisReq := *req
+	thisReq.RecurrenceRule = nil
+	updatedTask, err := s.UpdateTask(taskID, userID, &thisReq, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RecurrenceRule == nil {
+		shared := model.TaskUpdateRequest{
+			Title:       req.Title,
+			Description: req.Description,
+			Priority:    req.Priority,
+			ProjectID:   req.ProjectID,
+			AssigneeID:  req.AssigneeID,
+			LabelIDs:    req.LabelIDs,
+		}
+		for _, occurrence := range future {
+			if _, err := s.UpdateTask(occurrence.ID, userID, &shared, isAdmin); err != nil {
+				return nil, err
+			}
+		}
+		return updatedTask, nil
+	}
+
+	series, err := s.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task series: %w", err)
+	}
+	if series != nil {
+		series.Rule, err = recurrence.EndBefore(series.Rule, series.StartAt, occurrenceAt)
+		if err != nil {
+			return nil, errors.New(model.ErrInvalidRecurrence)
+		}
+		if err := s.seriesRepo.Update(series); err != nil {
+			return nil, fmt.Errorf("failed to update task series: %w", err)
+		}
+	}
+
+	for _, occurrence := range future {
+		if err := s.DeleteTask(occurrence.ID, userID, isAdmin, model.SubtaskDeleteReparent); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *updatedTask
+	updatedTask.SeriesID, updatedTask.OccurrenceAt, updatedTask.RecurrenceRule = nil, nil, nil
+	if newRule != "" {
+		if err := s.startSeries(updatedTask, newRule); err != nil {
+			return nil, err
+		}
+	}
+	err = s.taskRepo.Update(updatedTask)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return nil, errors.New(model.ErrTaskModified)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	finalTask, err := s.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated task: %w", err)
+	}
+
+	if err := s.activityRepo.Create(taskChanges(&before, finalTask, userID)); err != nil {
+		return nil, fmt.Errorf("failed to record task activity: %w", err)
+	}
+
+	return finalTask, nil
+}
+
+// openOccurrences menyaring occurrence yang belum dihapus dan belum selesai
+func (s *taskService) openOccurrences(occurrences []model.Task) ([]model.Task, error) {
+	var open []model.Task
+	for _, occurrence := range occurrences {
+		if occurrence.DeletedAt != nil {
+			continue
+		}
+		done, err := s.statusDone(occurrence.ProjectID, occurrence.Status)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			open = append(open, occurrence)
+		}
+	}
+	return open, nil
+}
+
+// GenerateOccurrences membuat sampai count occurrence berikutnya setelah
+// occurrence terakhir dalam series task
+func (s *taskService) GenerateOccurrences(taskID, userID int, count int, isAdmin bool) ([]model.Task, error) {
+	task, err := s.GetTaskByID(taskID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+	if task.SeriesID == nil || task.OccurrenceAt == nil {
+		return nil, errors.New(model.ErrTaskNotRecurring)
+	}
+
+	series, err := s.seriesRepo.GetByID(*task.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task series: %w", err)
+	}
+	if series == nil {
+		return nil, errors.New(model.ErrTaskNotRecurring)
+	}
+
+	// Occurrence baru disalin dari occurrence terakhir yang sudah ada
+	latest := task
+	later, err := s.taskRepo.GetSeriesOccurrences(series.ID, *task.OccurrenceAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	if len(later) > 0 {
+		latest = &later[len(later)-1]
+	}
+
+	times, err := recurrence.Next(series.Rule, series.StartAt, *latest.OccurrenceAt, count)
+	if err != nil {
+		return nil, errors.New(model.ErrInvalidRecurrence)
+	}
+
+	// Semua occurrence dibuat dalam satu transaksi
+	occurrences := []model.Task{}
+	err = s.withTx(func(tx *taskService) error {
+		for _, at := range times {
+			occurrence, err := tx.createOccurrence(latest, series.ID, at, userID)
+			if err != nil {
+				return err
+			}
+			occurrences = append(occurrences, *occurrence)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return occurrences, nil
+}
+
+// startSeries membuat series baru dengan task sebagai occurrence pertama.
+// Jadwal dihitung dari due date task.
+func (s *taskService) startSeries(task *model.Task, rule string) error {
+	normalized, err := normalizeRule(rule)
+	if err != nil {
+		return err
+	}
+	if task.DueAt == nil {
+		return errors.New(model.ErrRecurrenceRequiresDueDate)
+	}
+
+	series := &model.TaskSeries{
+		UserID:  task.UserID,
+		Rule:    normalized,
+		StartAt: *task.DueAt,
+	}
+	if err := s.seriesRepo.Create(series); err != nil {
+		return fmt.Errorf("failed to create task series: %w", err)
+	}
+
+	occurrenceAt := *task.DueAt
+	task.SeriesID = &series.ID
+	task.OccurrenceAt = &occurrenceAt
+	task.RecurrenceRule = &normalized
+	return nil
+}
+
+// ensureNextOccurrence memastikan occurrence setelah task sudah ada,
+// dipanggil saat sebuah occurrence diselesaikan
+func (s *taskService) ensureNextOccurrence(task *model.Task, actorID int) error {
+	if task.SeriesID == nil || task.OccurrenceAt == nil {
+		return nil
+	}
+
+	series, err := s.seriesRepo.GetByID(*task.SeriesID)
+	if err != nil {
+		return fmt.Errorf("failed to get task series: %w", err)
+	}
+	if series == nil {
+		return nil
+	}
+
+	later, err := s.taskRepo.GetSeriesOccurrences(series.ID, *task.OccurrenceAt)
+	if err != nil {
+		return fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	if len(later) > 0 {
+		return nil
+	}
+
+	times, err := recurrence.Next(series.Rule, series.StartAt, *task.OccurrenceAt, 1)
+	if err != nil {
+		return errors.New(model.ErrInvalidRecurrence)
+	}
+	if len(times) == 0 {
+		return nil
+	}
+
+	_, err = s.createOccurrence(task, series.ID, times[0], actorID)
+	return err
+}
+
+// createOccurrence membuat occurrence baru pada waktu at dengan menyalin
+// template. Jarak start_at ke due_at pada template dipertahankan.
+func (s *taskService) createOccurrence(template *model.Task, seriesID int, at time.Time, actorID int) (*model.Task, error) {
+	workflow, err := s.workflowFor(template.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	dueAt := at
+	task := &model.Task{
+		UserID:       template.UserID,
+		ParentTaskID: template.ParentTaskID,
+		ProjectID:    template.ProjectID,
+		AssigneeID:   template.AssigneeID,
+		SeriesID:     &seriesID,
+		OccurrenceAt: &at,
+		Title:        template.Title,
+		Description:  template.Description,
+		Status:       workflow.InitialStatus(),
+		Priority:     template.Priority,
+		DueAt:        &dueAt,
+	}
+	if template.StartAt != nil && template.DueAt != nil {
+		startAt := at.Add(-template.DueAt.Sub(*template.StartAt))
+		task.StartAt = &startAt
+	}
+
+	if err := s.taskRepo.Create(task); err != nil {
+		return nil, fmt.Errorf("failed to create occurrence: %w", err)
+	}
+
+	if len(template.Labels) > 0 {
+		labelIDs := make([]int, len(template.Labels))
+		for i, label := range template.Labels {
+			labelIDs[i] = label.ID
+		}
+		if err := s.taskRepo.SetLabels(task.ID, labelIDs); err != nil {
+			return nil, fmt.Errorf("failed to set task labels: %w", err)
+		}
+	}
+
+	activity := newTaskActivity(task.ID, actorID, model.ActivityCreated)
+	activity.NewValue = stringValue(task.Title)
+	if err := s.activityRepo.Create([]model.TaskActivity{activity}); err != nil {
+		return nil, fmt.Errorf("failed to record task activity: %w", err)
+	}
+
+	createdTask, err := s.taskRepo.GetByID(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created occurrence: %w", err)
+	}
+
+	return createdTask, nil
+}
+
+// normalizeRule memvalidasi RRULE dan mengembalikan bentuk kanoniknya
+func normalizeRule(rule string) (string, error) {
+	normalized, err := recurrence.Normalize(rule)
+	if err != nil {
+		return "", errors.New(model.ErrInvalidRecurrence)
+	}
+	return normalized, nil
+}
@@ -3,56 +3,190 @@ package service
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Mahathirrr/task-management-backend/internal/model"
 	"github.com/Mahathirrr/task-management-backend/internal/repository"
+	"github.com/Mahathirrr/task-management-backend/pkg/search"
+	"github.com/Mahathirrr/task-management-backend/pkg/storage"
 )
 
 type TaskService interface {
 	CreateTask(userID int, req *model.TaskCreateRequest) (*model.Task, error)
 	GetTaskByID(taskID, userID int, isAdmin bool) (*model.Task, error)
-	GetUserTasks(userID int, page, limit int, status, search string) (*model.TasksResponse, error)
-	GetAllTasks(page, limit int, status, search string) (*model.TasksResponse, error)
+	GetUserTasks(userID int, page model.TaskPage, filter model.TaskFilter) (*model.TasksResponse, error)
+	GetAllTasks(page model.TaskPage, filter model.TaskFilter) (*model.TasksResponse, error)
 	UpdateTask(taskID, userID int, req *model.TaskUpdateRequest, isAdmin bool) (*model.Task, error)
-	DeleteTask(taskID, userID int, isAdmin bool) error
+	PatchTask(taskID, userID int, patch *model.TaskPatch, isAdmin bool) (*model.Task, error)
+	DeleteTask(taskID, userID int, isAdmin bool, mode model.SubtaskDeleteMode) error
+	CreateSubtask(parentID, userID int, req *model.TaskCreateRequest, isAdmin bool) (*model.Task, error)
+	GetSubtasks(parentID, userID int, isAdmin bool) ([]model.Task, error)
+	GetTaskActivity(taskID, userID int, page, limit int, isAdmin bool) (*model.ActivitiesResponse, error)
+	UpdateTaskSeries(taskID, userID int, req *model.TaskUpdateRequest, isAdmin bool) (*model.Task, error)
+	GenerateOccurrences(taskID, userID int, count int, isAdmin bool) ([]model.Task, error)
+	GetTrash(userID int, page, limit int, isAdmin bool) (*model.TasksResponse, error)
+	RestoreTask(taskID, userID int, isAdmin bool) (*model.Task, error)
+	PurgeTrash(deletedBefore time.Time) (int, error)
+	BulkUpdateTasks(userID int, req *model.TaskBulkRequest, isAdmin bool) (*model.TaskBulkResponse, error)
+	AddDependency(taskID, blockerID, userID int, isAdmin bool) (*model.Task, error)
+	RemoveDependency(taskID, blockerID, userID int, isAdmin bool) error
+	ToggleChecklistItem(taskID, index, userID int, req *model.TaskChecklistRequest, isAdmin bool) (*model.Task, error)
+	MoveTask(taskID, userID int, req *model.TaskMoveRequest, isAdmin bool) (*model.Task, error)
+	GetBoard(userID int, filter model.TaskFilter, limit int, isAdmin bool) (*model.BoardResponse, error)
+	ExportTasks(userID int, filter model.TaskFilter, isAdmin bool, fn func(task *model.Task) error) error
+	ImportTasks(userID int, rows []model.TaskImportRow, dryRun bool) (*model.TaskImportResponse, error)
+	WithTransaction(fn func(tx TaskService) error) error
 }
 
 type taskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo       repository.TaskRepository
+	labelRepo      repository.LabelRepository
+	projectRepo    repository.ProjectRepository
+	userRepo       repository.UserRepository
+	attachmentRepo repository.AttachmentRepository
+	fileStorage    storage.Storage
+	activityRepo   repository.ActivityRepository
+	workflowRepo   repository.WorkflowRepository
+	seriesRepo     repository.SeriesRepository
+	transactor     repository.Transactor
 }
 
-func NewTaskService(taskRepo repository.TaskRepository) TaskService {
+func NewTaskService(taskRepo repository.TaskRepository, labelRepo repository.LabelRepository, projectRepo repository.ProjectRepository, userRepo repository.UserRepository, attachmentRepo repository.AttachmentRepository, fileStorage storage.Storage, activityRepo repository.ActivityRepository, workflowRepo repository.WorkflowRepository, seriesRepo repository.SeriesRepository, transactor repository.Transactor) TaskService {
 	return &taskService{
-		taskRepo: taskRepo,
+		taskRepo:       taskRepo,
+		labelRepo:      labelRepo,
+		projectRepo:    projectRepo,
+		userRepo:       userRepo,
+		attachmentRepo: attachmentRepo,
+		fileStorage:    fileStorage,
+		activityRepo:   activityRepo,
+		workflowRepo:   workflowRepo,
+		seriesRepo:     seriesRepo,
+		transactor:     transactor,
 	}
 }
 
+// WithTransaction menjalankan fn dengan TaskService yang memakai satu
+// transaksi database. Semua perubahan dibatalkan jika fn mengembalikan error.
+func (s *taskService) WithTransaction(fn func(tx TaskService) error) error {
+	return s.withTx(func(tx *taskService) error {
+		return fn(tx)
+	})
+}
+
+// withTx seperti WithTransaction untuk dipakai di dalam service. Pemanggilan
+// bertingkat ikut transaksi yang sudah berjalan.
+func (s *taskService) withTx(fn func(tx *taskService) error) error {
+	return s.transactor.WithinTx(func(repos repository.TxRepositories) error {
+		tx := *s
+		tx.taskRepo = repos.Tasks
+		tx.activityRepo = repos.Activities
+		tx.seriesRepo = repos.Series
+		tx.transactor = repository.JoinTx(repos)
+		return fn(&tx)
+	})
+}
+
 // CreateTask membuat task baru
 func (s *taskService) CreateTask(userID int, req *model.TaskCreateRequest) (*model.Task, error) {
+	return s.createTask(userID, userID, nil, req)
+}
+
+// createTask membuat task baru milik userID atas nama actorID, parentID nil untuk task root
+func (s *taskService) createTask(userID, actorID int, parentID *int, req *model.TaskCreateRequest) (*model.Task, error) {
+	task, err := s.prepareTask(userID, parentID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Series, task, label, dan activity disimpan dalam satu transaksi
+	var createdTask *model.Task
+	err = s.withTx(func(tx *taskService) error {
+		if req.RecurrenceRule != nil && *req.RecurrenceRule != "" {
+			if err := tx.startSeries(task, *req.RecurrenceRule); err != nil {
+				return err
+			}
+		}
+		if err := tx.taskRepo.Create(task); err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+
+		if len(req.LabelIDs) > 0 {
+			if err := tx.taskRepo.SetLabels(task.ID, req.LabelIDs); err != nil {
+				return fmt.Errorf("failed to set task labels: %w", err)
+			}
+		}
+
+		activity := newTaskActivity(task.ID, actorID, model.ActivityCreated)
+		activity.NewValue = stringValue(task.Title)
+		if err := tx.activityRepo.Create([]model.TaskActivity{activity}); err != nil {
+			return fmt.Errorf("failed to record task activity: %w", err)
+		}
+
+		// Get the created task with timestamps
+		var err error
+		createdTask, err = tx.taskRepo.GetByID(task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get created task: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdTask, nil
+}
+
+// prepareTask menyusun task baru dari request dan memvalidasinya tanpa
+// menyimpan apa pun
+func (s *taskService) prepareTask(userID int, parentID *int, req *model.TaskCreateRequest) (*model.Task, error) {
 	task := &model.Task{
-		UserID:      userID,
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      model.TaskStatusPending, // Default status
+		ParentTaskID: parentID,
+		ProjectID:    req.ProjectID,
+		AssigneeID:   req.AssigneeID,
+		UserID:       userID,
+		Title:        req.Title,
+		Description:  req.Description,
+		Status:       model.TaskStatusPending, // Default status
+		Priority:     model.TaskPriorityMedium,
+		StartAt:      req.StartAt,
+		DueAt:        req.DueAt,
 	}
 
-	// Override status if provided
-	if req.Status != "" {
-		task.Status = req.Status
+	if !validDateRange(task.StartAt, task.DueAt) {
+		return nil, errors.New(model.ErrInvalidDateRange)
 	}
 
-	err := s.taskRepo.Create(task)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create task: %w", err)
+	if err := checkLabelsOwnedBy(s.labelRepo, userID, req.LabelIDs); err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectOwnedBy(userID, task.ProjectID); err != nil {
+		return nil, err
+	}
+	if err := s.checkAssigneeExists(task.AssigneeID); err != nil {
+		return nil, err
 	}
 
-	// Get the created task with timestamps
-	createdTask, err := s.taskRepo.GetByID(task.ID)
+	// Status awal mengikuti workflow project, kecuali ditentukan oleh request
+	workflow, err := s.workflowFor(task.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get created task: %w", err)
+		return nil, err
+	}
+	task.Status = workflow.InitialStatus()
+	if req.Status != "" {
+		if !workflow.HasStatus(req.Status) {
+			return nil, errors.New(model.ErrInvalidStatus)
+		}
+		task.Status = req.Status
 	}
 
-	return createdTask, nil
+	// Override priority if provided
+	if req.Priority != "" {
+		task.Priority = req.Priority
+	}
+
+	return task, nil
 }
 
 // GetTaskByID mengambil task berdasarkan ID dengan authorization check
@@ -65,8 +199,8 @@ func (s *taskService) GetTaskByID(taskID, userID int, isAdmin bool) (*model.Task
 		return nil, errors.New(model.ErrTaskNotFound)
 	}
 
-	// Check authorization
-	if !isAdmin && task.UserID != userID {
+	// Check authorization: pemilik, assignee, atau admin
+	if !canReadTask(task, userID, isAdmin) {
 		return nil, errors.New(model.ErrForbidden)
 	}
 
@@ -74,33 +208,77 @@ func (s *taskService) GetTaskByID(taskID, userID int, isAdmin bool) (*model.Task
 }
 
 // GetUserTasks mengambil tasks milik user dengan pagination dan filter
-func (s *taskService) GetUserTasks(userID int, page, limit int, status, search string) (*model.TasksResponse, error) {
-	tasks, total, err := s.taskRepo.GetByUserID(userID, page, limit, status, search)
+func (s *taskService) GetUserTasks(userID int, page model.TaskPage, filter model.TaskFilter) (*model.TasksResponse, error) {
+	result, err := s.taskRepo.GetByUserID(userID, page, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user tasks: %w", err)
 	}
 
-	return &model.TasksResponse{
-		Tasks: tasks,
-		Total: total,
-		Page:  page,
-		Limit: limit,
-	}, nil
+	return newTasksResponse(result, page, filter), nil
 }
 
 // GetAllTasks mengambil semua tasks dengan pagination dan filter (admin only)
-func (s *taskService) GetAllTasks(page, limit int, status, search string) (*model.TasksResponse, error) {
-	tasks, total, err := s.taskRepo.GetAll(page, limit, status, search)
+func (s *taskService) GetAllTasks(page model.TaskPage, filter model.TaskFilter) (*model.TasksResponse, error) {
+	result, err := s.taskRepo.GetAll(page, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all tasks: %w", err)
 	}
 
-	return &model.TasksResponse{
-		Tasks: tasks,
-		Total: total,
-		Page:  page,
-		Limit: limit,
-	}, nil
+	return newTasksResponse(result, page, filter), nil
+}
+
+// newTasksResponse menyusun response list tasks beserta cursor halaman
+// berikutnya dan sebelumnya. Cursor hanya diberikan untuk sort berbasis
+// created_at; page hanya diisi pada pagination page/limit.
+func newTasksResponse(result *model.TaskPageResult, page model.TaskPage, filter model.TaskFilter) *model.TasksResponse {
+	resp := &model.TasksResponse{
+		Tasks: result.Tasks,
+		Total: result.Total,
+		Limit: page.Limit,
+	}
+	if page.Cursor == nil {
+		resp.Page = page.Page
+	}
+	if filter.Search != "" {
+		highlightTasks(result.Tasks, result.MatchedComments, search.Parse(filter.Search))
+	}
+
+	if len(result.Tasks) == 0 || !model.SupportsCursor(filter.Sort) {
+		return resp
+	}
+
+	if result.HasNext {
+		last := result.Tasks[len(result.Tasks)-1]
+		resp.NextCursor = model.TaskCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if result.HasPrev {
+		first := result.Tasks[0]
+		resp.PrevCursor = model.TaskCursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true}.Encode()
+	}
+
+	return resp
+}
+
+// snippetLength adalah panjang maksimum snippet deskripsi dan komentar
+const snippetLength = 160
+
+// highlightTasks mengisi Highlights setiap task dengan bagian title,
+// description, dan komentar yang cocok dengan pencarian
+func highlightTasks(tasks []model.Task, comments map[int]string, query search.Query) {
+	for i := range tasks {
+		task := &tasks[i]
+		highlights := model.TaskHighlights{
+			Title:   search.Highlight(task.Title, query, len(task.Title)),
+			Comment: search.Highlight(comments[task.ID], query, snippetLength),
+		}
+		if task.Description != nil {
+			highlights.Description = search.Highlight(*task.Description, query, snippetLength)
+		}
+
+		if highlights != (model.TaskHighlights{}) {
+			task.Highlights = &highlights
+		}
+	}
 }
 
 // UpdateTask mengupdate task dengan authorization check
@@ -114,10 +292,20 @@ func (s *taskService) UpdateTask(taskID, userID int, req *model.TaskUpdateReques
 		return nil, errors.New(model.ErrTaskNotFound)
 	}
 
-	// Check authorization
-	if !isAdmin && task.UserID != userID {
+	// Check authorization: assignee hanya boleh mengubah status
+	if !canReadTask(task, userID, isAdmin) {
 		return nil, errors.New(model.ErrForbidden)
 	}
+	if !canManageTask(task, userID, isAdmin) && !req.StatusOnly() {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	if !task.MatchesVersion(req.IfMatch) {
+		return nil, errors.New(model.ErrTaskModified)
+	}
+
+	// Salinan state awal untuk mencatat field yang berubah
+	before := *task
 
 	// Update fields if provided
 	if req.Title != nil {
@@ -129,23 +317,216 @@ func (s *taskService) UpdateTask(taskID, userID int, req *model.TaskUpdateReques
 	if req.Status != nil {
 		task.Status = *req.Status
 	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.StartAt != nil {
+		task.StartAt = req.StartAt
+	}
+	if req.DueAt != nil {
+		task.DueAt = req.DueAt
+	}
+	if req.ProjectID != nil {
+		task.ProjectID = req.ProjectID
+		if *req.ProjectID == 0 {
+			task.ProjectID = nil
+		}
+	}
+	if req.AssigneeID != nil {
+		task.AssigneeID = req.AssigneeID
+		if *req.AssigneeID == 0 {
+			task.AssigneeID = nil
+		}
+	}
+
+	if err := s.validateTaskChanges(&before, task, req.LabelIDs); err != nil {
+		return nil, err
+	}
+	if err := s.checkBlockersCompleted(&before, task, isAdmin); err != nil {
+		return nil, err
+	}
+
+	// Mengubah pengulangan task yang sudah berulang harus lewat scope=future
+	if req.RecurrenceRule != nil && before.SeriesID != nil {
+		return nil, errors.New(model.ErrRecurrenceScope)
+	}
 
-	err = s.taskRepo.Update(task)
+	// Series baru disimpan dalam transaksi yang sama dengan task agar tidak
+	// tertinggal saat update gagal
+	var updatedTask *model.Task
+	err = s.withTx(func(tx *taskService) error {
+		if req.RecurrenceRule != nil && *req.RecurrenceRule != "" {
+			if err := tx.startSeries(task, *req.RecurrenceRule); err != nil {
+				return err
+			}
+		}
+		var err error
+		updatedTask, err = tx.saveTaskChanges(&before, task, req.LabelIDs, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+// PatchTask menyimpan hasil PATCH. Berbeda dengan UpdateTask, semua field
+// pada patch dipakai apa adanya sehingga nil mengosongkan field nullable.
+func (s *taskService) PatchTask(taskID, userID int, patch *model.TaskPatch, isAdmin bool) (*model.Task, error) {
+	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+
+	if !canReadTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	// Patch dibuat dari task versi tertentu, perubahan sesudahnya tidak boleh tertimpa
+	if task.Version != patch.Version {
+		return nil, errors.New(model.ErrTaskModified)
+	}
+
+	before := *task
+	task.Title = patch.Title
+	task.Description = patch.Description
+	task.Status = patch.Status
+	task.Priority = patch.Priority
+	task.StartAt = patch.StartAt
+	task.DueAt = patch.DueAt
+	task.ProjectID = patch.ProjectID
+	task.AssigneeID = patch.AssigneeID
+
+	labelIDs := patch.LabelIDs
+	if labelIDs == nil {
+		labelIDs = []int{}
+	}
+
+	// Assignee hanya boleh mengubah status
+	if !canManageTask(task, userID, isAdmin) {
+		after := *task
+		after.Labels = make([]model.Label, len(labelIDs))
+		for i, labelID := range labelIDs {
+			after.Labels[i] = model.Label{ID: labelID}
+		}
+		for _, change := range taskChanges(&before, &after, userID) {
+			if *change.Field != "status" {
+				return nil, errors.New(model.ErrForbidden)
+			}
+		}
 	}
 
-	// Get updated task
+	if err := s.validateTaskChanges(&before, task, &labelIDs); err != nil {
+		return nil, err
+	}
+	if err := s.checkBlockersCompleted(&before, task, isAdmin); err != nil {
+		return nil, err
+	}
+
+	return s.saveTaskChanges(&before, task, &labelIDs, userID)
+}
+
+// validateTaskChanges memvalidasi perubahan task dari before, labelIDs nil
+// berarti labels tidak berubah
+func (s *taskService) validateTaskChanges(before, task *model.Task, labelIDs *[]int) error {
+	if !validDateRange(task.StartAt, task.DueAt) {
+		return errors.New(model.ErrInvalidDateRange)
+	}
+
+	projectChanged := !sameValue(intValue(before.ProjectID), intValue(task.ProjectID))
+	if projectChanged {
+		if err := s.checkProjectOwnedBy(task.UserID, task.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	if !sameValue(intValue(before.AssigneeID), intValue(task.AssigneeID)) {
+		if err := s.checkAssigneeExists(task.AssigneeID); err != nil {
+			return err
+		}
+	}
+
+	if labelIDs != nil {
+		if err := checkLabelsOwnedBy(s.labelRepo, task.UserID, *labelIDs); err != nil {
+			return err
+		}
+	}
+
+	// Status yang tidak berubah tetap harus ada di workflow project baru
+	if task.Status != before.Status || projectChanged {
+		if err := s.checkStatusTransition(task.ProjectID, before.Status, task.Status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveTaskChanges menyimpan task yang sudah divalidasi, mencatat riwayat
+// perubahan, dan membuat occurrence berikutnya jika task berulang selesai.
+// Semua langkah berjalan dalam satu transaksi.
+func (s *taskService) saveTaskChanges(before, task *model.Task, labelIDs *[]int, userID int) (*model.Task, error) {
+	var updatedTask *model.Task
+	err := s.withTx(func(tx *taskService) error {
+		// Version dicek ulang secara atomik oleh UPDATE untuk menangkap
+		// perubahan dari request lain sejak task dibaca
+		err := tx.taskRepo.Update(task)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return errors.New(model.ErrTaskModified)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+
+		if labelIDs != nil {
+			if err := tx.taskRepo.SetLabels(task.ID, *labelIDs); err != nil {
+				return fmt.Errorf("failed to set task labels: %w", err)
+			}
+		}
+
+		updatedTask, err = tx.recordTaskChanges(before, task.ID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+// recordTaskChanges mengambil task yang baru disimpan, mencatat riwayat
+// perubahannya dari before, dan membuat occurrence berikutnya jika task
+// berulang baru saja selesai
+func (s *taskService) recordTaskChanges(before *model.Task, taskID, userID int) (*model.Task, error) {
 	updatedTask, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get updated task: %w", err)
 	}
 
+	if err := s.activityRepo.Create(taskChanges(before, updatedTask, userID)); err != nil {
+		return nil, fmt.Errorf("failed to record task activity: %w", err)
+	}
+
+	// Menyelesaikan occurrence task berulang membuat occurrence berikutnya
+	done, err := s.becameDone(before, updatedTask)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		if err := s.ensureNextOccurrence(updatedTask, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	return updatedTask, nil
 }
 
-// DeleteTask menghapus task dengan authorization check
-func (s *taskService) DeleteTask(taskID, userID int, isAdmin bool) error {
+// DeleteTask memindahkan task ke trash dengan authorization check. Subtasks
+// ikut dihapus (cascade) atau dipindahkan ke parent dari task yang dihapus
+// (reparent). File attachment baru dihapus saat trash di-purge.
+func (s *taskService) DeleteTask(taskID, userID int, isAdmin bool, mode model.SubtaskDeleteMode) error {
 	// Get existing task for authorization check
 	task, err := s.taskRepo.GetByID(taskID)
 	if err != nil {
@@ -155,15 +536,297 @@ func (s *taskService) DeleteTask(taskID, userID int, isAdmin bool) error {
 		return errors.New(model.ErrTaskNotFound)
 	}
 
-	// Check authorization
-	if !isAdmin && task.UserID != userID {
+	// Check authorization: hanya pemilik atau admin
+	if !canManageTask(task, userID, isAdmin) {
 		return errors.New(model.ErrForbidden)
 	}
 
-	err = s.taskRepo.Delete(taskID)
+	// Reparent, soft delete, dan activity dibatalkan bersama jika salah satu gagal
+	return s.withTx(func(tx *taskService) error {
+		deletedIDs := []int{taskID}
+		if mode == model.SubtaskDeleteCascade {
+			subtaskIDs, err := tx.descendantIDs(taskID)
+			if err != nil {
+				return err
+			}
+			deletedIDs = append(deletedIDs, subtaskIDs...)
+		}
+
+		if mode == model.SubtaskDeleteReparent {
+			if err := tx.taskRepo.ReparentSubtasks(taskID, task.ParentTaskID); err != nil {
+				return fmt.Errorf("failed to reparent subtasks: %w", err)
+			}
+		}
+
+		err := tx.taskRepo.SoftDelete(deletedIDs, time.Now().Truncate(time.Second))
+		if err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+
+		activities := make([]model.TaskActivity, len(deletedIDs))
+		for i, deletedID := range deletedIDs {
+			activities[i] = newTaskActivity(deletedID, userID, model.ActivityDeleted)
+		}
+		if err := tx.activityRepo.Create(activities); err != nil {
+			return fmt.Errorf("failed to record task activity: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetTaskActivity mengambil riwayat perubahan task dengan pagination
+func (s *taskService) GetTaskActivity(taskID, userID int, page, limit int, isAdmin bool) (*model.ActivitiesResponse, error) {
+	if _, err := s.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	activities, total, err := s.activityRepo.GetByTaskID(taskID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task activity: %w", err)
+	}
+
+	if activities == nil {
+		activities = []model.TaskActivity{}
+	}
+
+	return &model.ActivitiesResponse{
+		Activities: activities,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+	}, nil
+}
+
+// descendantIDs mengambil ID semua subtask di bawah task secara rekursif
+func (s *taskService) descendantIDs(taskID int) ([]int, error) {
+	subtasks, err := s.taskRepo.GetSubtasks(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	var ids []int
+	for _, subtask := range subtasks {
+		childIDs, err := s.descendantIDs(subtask.ID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, subtask.ID)
+		ids = append(ids, childIDs...)
+	}
+
+	return ids, nil
+}
+
+// CreateSubtask membuat subtask di bawah parent. Subtask selalu dimiliki oleh
+// pemilik parent, termasuk saat dibuat oleh admin.
+func (s *taskService) CreateSubtask(parentID, userID int, req *model.TaskCreateRequest, isAdmin bool) (*model.Task, error) {
+	parent, err := s.GetTaskByID(parentID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageTask(parent, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	depth, err := s.taskDepth(parent)
+	if err != nil {
+		return nil, err
+	}
+	if depth+1 > model.MaxTaskDepth {
+		return nil, errors.New(model.ErrMaxTaskDepth)
+	}
+
+	// Subtask mengikuti project parent jika tidak ditentukan
+	if req.ProjectID == nil {
+		req.ProjectID = parent.ProjectID
+	}
+
+	return s.createTask(parent.UserID, userID, &parent.ID, req)
+}
+
+// GetSubtasks mengambil subtasks langsung dari sebuah task
+func (s *taskService) GetSubtasks(parentID, userID int, isAdmin bool) ([]model.Task, error) {
+	if _, err := s.GetTaskByID(parentID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.taskRepo.GetSubtasks(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	return subtasks, nil
+}
+
+// taskDepth menghitung kedalaman task dalam pohon (task root = 1)
+func (s *taskService) taskDepth(task *model.Task) (int, error) {
+	depth := 1
+	for current := task; current.ParentTaskID != nil; depth++ {
+		parent, err := s.taskRepo.GetByID(*current.ParentTaskID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent == nil || depth > model.MaxTaskDepth {
+			break
+		}
+		current = parent
+	}
+
+	return depth, nil
+}
+
+// checkLabelsOwnedBy memastikan semua label ada dan milik user. Dipakai
+// bersama oleh TaskService dan TemplateService.
+func checkLabelsOwnedBy(labelRepo repository.LabelRepository, userID int, labelIDs []int) error {
+	if len(labelIDs) == 0 {
+		return nil
+	}
+
+	labels, err := labelRepo.GetByIDs(labelIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	owned := make(map[int]bool, len(labels))
+	for _, label := range labels {
+		if label.UserID == userID {
+			owned[label.ID] = true
+		}
+	}
+	for _, id := range labelIDs {
+		if !owned[id] {
+			return errors.New(model.ErrInvalidLabels)
+		}
+	}
+
+	return nil
+}
+
+// canReadTask mengecek apakah user boleh melihat task (pemilik, assignee, atau admin)
+func canReadTask(task *model.Task, userID int, isAdmin bool) bool {
+	return isAdmin || task.UserID == userID || (task.AssigneeID != nil && *task.AssigneeID == userID)
+}
+
+// canManageTask mengecek apakah user boleh mengubah seluruh task (pemilik atau admin)
+func canManageTask(task *model.Task, userID int, isAdmin bool) bool {
+	return isAdmin || task.UserID == userID
+}
+
+// checkAssigneeExists memastikan user yang di-assign ada
+func (s *taskService) checkAssigneeExists(assigneeID *int) error {
+	if assigneeID == nil {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(*assigneeID)
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return fmt.Errorf("failed to get assignee: %w", err)
+	}
+	if user == nil {
+		return errors.New(model.ErrAssigneeNotFound)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// workflowFor mengambil workflow yang berlaku untuk task pada project,
+// yaitu workflow project atau workflow default
+func (s *taskService) workflowFor(projectID *int) (*model.Workflow, error) {
+	if projectID != nil {
+		project, err := s.projectRepo.GetByID(*projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		if project != nil && project.WorkflowID != nil {
+			workflow, err := s.workflowRepo.GetByID(*project.WorkflowID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow: %w", err)
+			}
+			if workflow != nil {
+				return workflow, nil
+			}
+		}
+	}
+
+	workflow, err := s.workflowRepo.GetDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default workflow: %w", err)
+	}
+	if workflow == nil {
+		return model.NewDefaultWorkflow(), nil
+	}
+
+	return workflow, nil
+}
+
+// statusDone mengecek apakah status ditandai is_done pada workflow yang
+// berlaku untuk task pada project
+func (s *taskService) statusDone(projectID *int, status model.TaskStatus) (bool, error) {
+	workflow, err := s.workflowFor(projectID)
+	if err != nil {
+		return false, err
+	}
+	return workflow.IsDone(status), nil
+}
+
+// becameDone mengecek apakah perubahan dari before membuat task selesai,
+// yaitu status task sekarang is_done dan sebelumnya belum
+func (s *taskService) becameDone(before, task *model.Task) (bool, error) {
+	if before.Status == task.Status && sameValue(intValue(before.ProjectID), intValue(task.ProjectID)) {
+		return false, nil
+	}
+
+	done, err := s.statusDone(task.ProjectID, task.Status)
+	if err != nil || !done {
+		return false, err
+	}
+	wasDone, err := s.statusDone(before.ProjectID, before.Status)
+	if err != nil {
+		return false, err
+	}
+	return !wasDone, nil
+}
+
+// checkStatusTransition memastikan status tujuan ada di workflow dan
+// perpindahan dari status lama diizinkan
+func (s *taskService) checkStatusTransition(projectID *int, from, to model.TaskStatus) error {
+	workflow, err := s.workflowFor(projectID)
+	if err != nil {
+		return err
+	}
+
+	if !workflow.HasStatus(to) {
+		return errors.New(model.ErrInvalidStatus)
+	}
+	if !workflow.CanTransition(from, to) {
+		return errors.New(model.ErrInvalidTransition)
+	}
+
+	return nil
+}
+
+// checkProjectOwnedBy memastikan project ada dan milik user
+func (s *taskService) checkProjectOwnedBy(userID int, projectID *int) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := s.projectRepo.GetByID(*projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project == nil || project.UserID != userID {
+		return errors.New(model.ErrInvalidProject)
+	}
+
+	return nil
+}
+
+// validDateRange memastikan start_at tidak melewati due_at
+func validDateRange(startAt, dueAt *time.Time) bool {
+	if startAt == nil || dueAt == nil {
+		return true
+	}
+	return !startAt.After(*dueAt)
+}
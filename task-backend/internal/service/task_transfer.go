@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+// exportBatchSize adalah jumlah task yang dibaca per query saat export
+const exportBatchSize = 500
+
+// errImportRollback membatalkan transaksi import tanpa dianggap gagal
+var errImportRollback = errors.New("import rolled back")
+
+// importRowErrors memetakan error TaskService yang disebabkan isi baris
+// import ke field pada baris tersebut
+var importRowErrors = map[string]string{
+	model.ErrInvalidDateRange: "due_at",
+	model.ErrInvalidLabels:    "label_ids",
+	model.ErrInvalidProject:   "project_id",
+	model.ErrAssigneeNotFound: "assignee_id",
+	model.ErrInvalidStatus:    "status",
+	model.ErrMaxTaskDepth:     "parent_task_id",
+}
+
+// ExportTasks memanggil fn untuk setiap task yang cocok dengan filter, urut
+// dari yang paling lama dibuat sehingga parent selalu mendahului subtask.
+// Task dibaca per batch dengan cursor agar export besar tidak dimuat
+// sekaligus ke memory. Admin mengekspor task semua user.
+func (s *taskService) ExportTasks(userID int, filter model.TaskFilter, isAdmin bool, fn func(task *model.Task) error) error {
+	filter.Sort, filter.SortFields = model.TaskSortOldest, nil
+	page := model.TaskPage{Page: 1, Limit: exportBatchSize}
+
+	for {
+		var result *model.TaskPageResult
+		var err error
+		if isAdmin {
+			result, err = s.taskRepo.GetAll(page, filter)
+		} else {
+			result, err = s.taskRepo.GetByUserID(userID, page, filter)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export tasks: %w", err)
+		}
+
+		for i := range result.Tasks {
+			if err := fn(&result.Tasks[i]); err != nil {
+				return err
+			}
+		}
+
+		if !result.HasNext || len(result.Tasks) == 0 {
+			return nil
+		}
+		last := result.Tasks[len(result.Tasks)-1]
+		page = model.TaskPage{Limit: exportBatchSize, Cursor: &model.TaskCursor{CreatedAt: last.CreatedAt, ID: last.ID}}
+	}
+}
+
+// ImportTasks membuat task dari baris import dengan aturan yang sama seperti
+// create task biasa. Semua baris divalidasi lebih dulu tanpa transaksi agar
+// seluruh error bisa dilaporkan; task baru dibuat dalam satu transaksi jika
+// tidak ada baris yang gagal dan dryRun false.
+func (s *taskService) ImportTasks(userID int, rows []model.TaskImportRow, dryRun bool) (*model.TaskImportResponse, error) {
+	rowErrors, err := s.validateImport(userID, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &model.TaskImportResponse{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Valid:  len(rows) - len(rowErrors),
+		Errors: rowErrors,
+	}
+	if dryRun || len(rowErrors) > 0 {
+		return resp, nil
+	}
+
+	var taskIDs []int
+	err = s.WithTransaction(func(tx TaskService) error {
+		// ID pada file -> ID task baru
+		created := map[int]int{}
+
+		for _, row := range rows {
+			taskID, err := importRecord(tx, userID, &row.Record, created)
+			if err != nil {
+				// Data terkait bisa berubah sejak validasi, misalnya label dihapus
+				field, ok := importRowErrors[err.Error()]
+				if !ok {
+					return err
+				}
+				resp.Errors = append(resp.Errors, model.TaskImportError{Row: row.Row, Errors: []model.ValidationError{{Field: field, Message: err.Error()}}})
+				return errImportRollback
+			}
+
+			taskIDs = append(taskIDs, taskID)
+			if row.Record.ID != 0 {
+				created[row.Record.ID] = taskID
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRollback) {
+		resp.Valid--
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Created = len(taskIDs)
+	resp.TaskIDs = taskIDs
+	return resp, nil
+}
+
+// importedRow adalah baris import yang valid, dipakai untuk memvalidasi
+// subtask di bawahnya
+type importedRow struct {
+	depth     int
+	projectID *int
+}
+
+// validateImport memeriksa setiap baris seperti create task biasa tanpa
+// menyimpan apa pun dan mengembalikan error per baris
+func (s *taskService) validateImport(userID int, rows []model.TaskImportRow) ([]model.TaskImportError, error) {
+	rowErrors := []model.TaskImportError{}
+	// ID pada file -> baris valid, nil jika baris tersebut gagal
+	seen := map[int]*importedRow{}
+
+	for _, row := range rows {
+		errs := row.Errors
+		var imported *importedRow
+		if len(errs) == 0 {
+			var err error
+			imported, err = s.validateImportRecord(userID, &row.Record, seen)
+			if err != nil {
+				field, ok := importRowErrors[err.Error()]
+				if !ok {
+					return nil, err
+				}
+				errs = []model.ValidationError{{Field: field, Message: err.Error()}}
+			} else if imported == nil {
+				errs = []model.ValidationError{{Field: "parent_task_id", Message: "parent_task_id must refer to an earlier valid row"}}
+			}
+		}
+
+		if row.Record.ID != 0 {
+			if _, exists := seen[row.Record.ID]; exists {
+				errs = append(errs, model.ValidationError{Field: "id", Message: "id must be unique within the import"})
+			} else if len(errs) == 0 {
+				seen[row.Record.ID] = imported
+			} else {
+				seen[row.Record.ID] = nil
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, model.TaskImportError{Row: row.Row, Errors: errs})
+		}
+	}
+
+	return rowErrors, nil
+}
+
+// validateImportRecord memvalidasi satu record import. Subtask mengikuti
+// project parent seperti CreateSubtask; nil dikembalikan jika parent tidak
+// ada atau gagal.
+func (s *taskService) validateImportRecord(userID int, record *model.TaskRecord, seen map[int]*importedRow) (*importedRow, error) {
+	req := importRequest(record)
+	imported := &importedRow{depth: 1}
+
+	if record.ParentTaskID != nil {
+		parent := seen[*record.ParentTaskID]
+		if parent == nil {
+			return nil, nil
+		}
+		if parent.depth+1 > model.MaxTaskDepth {
+			return nil, errors.New(model.ErrMaxTaskDepth)
+		}
+		imported.depth = parent.depth + 1
+		if req.ProjectID == nil {
+			req.ProjectID = parent.projectID
+		}
+	}
+
+	if _, err := s.prepareTask(userID, nil, req); err != nil {
+		return nil, err
+	}
+
+	imported.projectID = req.ProjectID
+	return imported, nil
+}
+
+// importRequest menyusun request create task dari record import
+func importRequest(record *model.TaskRecord) *model.TaskCreateRequest {
+	return &model.TaskCreateRequest{
+		Title:       record.Title,
+		Description: record.Description,
+		Status:      record.Status,
+		Priority:    record.Priority,
+		StartAt:     record.StartAt,
+		DueAt:       record.DueAt,
+		ProjectID:   record.ProjectID,
+		AssigneeID:  record.AssigneeID,
+		LabelIDs:    record.LabelIDs,
+	}
+}
+
+// importRecord membuat satu task dari record yang sudah divalidasi. Subtask
+// dibuat di bawah task yang dibuat dari baris parent-nya.
+func importRecord(tx TaskService, userID int, record *model.TaskRecord, created map[int]int) (int, error) {
+	req := importRequest(record)
+
+	if record.ParentTaskID == nil {
+		task, err := tx.CreateTask(userID, req)
+		if err != nil {
+			return 0, err
+		}
+		return task.ID, nil
+	}
+
+	task, err := tx.CreateSubtask(created[*record.ParentTaskID], userID, req, false)
+	if err != nil {
+		return 0, err
+	}
+	return task.ID, nil
+}
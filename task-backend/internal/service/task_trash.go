@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+// GetTrash mengambil task di trash. User hanya melihat task miliknya,
+// admin melihat semua.
+func (s *taskService) GetTrash(userID int, page, limit int, isAdmin bool) (*model.TasksResponse, error) {
+	var ownerID *int
+	if !isAdmin {
+		ownerID = &userID
+	}
+
+	tasks, total, err := s.taskRepo.GetTrash(ownerID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	if tasks == nil {
+		tasks = []model.Task{}
+	}
+
+	return &model.TasksResponse{
+		Tasks: tasks,
+		Total: &total,
+		Page:  page,
+		Limit: limit,
+	}, nil
+}
+
+// RestoreTask mengeluarkan task dari trash beserta subtasks yang ikut
+// terhapus bersamanya
+func (s *taskService) RestoreTask(taskID, userID int, isAdmin bool) (*model.Task, error) {
+	task, err := s.taskRepo.GetDeletedByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.New(model.ErrTaskNotFound)
+	}
+
+	if !canManageTask(task, userID, isAdmin) {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	// Subtask tidak bisa di-restore sendiri selama parent-nya masih di trash
+	if task.ParentTaskID != nil {
+		parent, err := s.taskRepo.GetByID(*task.ParentTaskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent task: %w", err)
+		}
+		if parent == nil {
+			return nil, errors.New(model.ErrParentTaskTrashed)
+		}
+	}
+
+	// Restore dan activity dibatalkan bersama jika salah satu gagal
+	var restoredTask *model.Task
+	err = s.withTx(func(tx *taskService) error {
+		restoredIDs, err := tx.taskRepo.Restore(taskID, *task.DeletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+
+		activities := make([]model.TaskActivity, len(restoredIDs))
+		for i, restoredID := range restoredIDs {
+			activities[i] = newTaskActivity(restoredID, userID, model.ActivityRestored)
+		}
+		if err := tx.activityRepo.Create(activities); err != nil {
+			return fmt.Errorf("failed to record task activity: %w", err)
+		}
+
+		restoredTask, err = tx.taskRepo.GetByID(taskID)
+		if err != nil {
+			return fmt.Errorf("failed to get restored task: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restoredTask, nil
+}
+
+// PurgeTrash menghapus permanen task yang masuk trash sebelum deletedBefore
+// beserta file attachment-nya, lalu mengembalikan jumlah task yang dihapus
+func (s *taskService) PurgeTrash(deletedBefore time.Time) (int, error) {
+	taskIDs, err := s.taskRepo.GetTrashedBefore(deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trashed tasks: %w", err)
+	}
+	if len(taskIDs) == 0 {
+		return 0, nil
+	}
+
+	// Metadata attachment ikut terhapus oleh foreign key, jadi file yang
+	// perlu dihapus dikumpulkan sebelum task dihapus
+	attachments, err := s.attachmentRepo.GetByTaskIDs(taskIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	if err := s.taskRepo.Purge(taskIDs); err != nil {
+		return 0, fmt.Errorf("failed to purge tasks: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if err := s.fileStorage.Delete(attachment.StorageKey); err != nil {
+			log.Printf("Failed to delete attachment file %s: %v", attachment.StorageKey, err)
+		}
+	}
+
+	return len(taskIDs), nil
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+// templatePlaceholder mencocokkan placeholder seperti {{date}} atau {{ name }}
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// maxTaskTitleLength sama dengan batas validate max=255 pada judul task
+const maxTaskTitleLength = 255
+
+type TemplateService interface {
+	CreateTemplate(userID int, req *model.TaskTemplateRequest) (*model.TaskTemplate, error)
+	GetUserTemplates(userID int) ([]model.TaskTemplate, error)
+	GetTemplateByID(templateID, userID int) (*model.TaskTemplate, error)
+	UpdateTemplate(templateID, userID int, req *model.TaskTemplateRequest) (*model.TaskTemplate, error)
+	DeleteTemplate(templateID, userID int) error
+	InstantiateTemplate(templateID, userID int, req *model.TemplateInstantiateRequest) (*model.TemplateInstantiateResponse, error)
+}
+
+type templateService struct {
+	templateRepo repository.TemplateRepository
+	labelRepo    repository.LabelRepository
+	userRepo     repository.UserRepository
+	taskService  TaskService
+}
+
+func NewTemplateService(templateRepo repository.TemplateRepository, labelRepo repository.LabelRepository, userRepo repository.UserRepository, taskService TaskService) TemplateService {
+	return &templateService{
+		templateRepo: templateRepo,
+		labelRepo:    labelRepo,
+		userRepo:     userRepo,
+		taskService:  taskService,
+	}
+}
+
+// CreateTemplate membuat template baru milik user
+func (s *templateService) CreateTemplate(userID int, req *model.TaskTemplateRequest) (*model.TaskTemplate, error) {
+	template := &model.TaskTemplate{UserID: userID}
+	if err := s.applyRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	createdTemplate, err := s.templateRepo.GetByID(template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get created template: %w", err)
+	}
+
+	return createdTemplate, nil
+}
+
+// GetUserTemplates mengambil semua template milik user
+func (s *templateService) GetUserTemplates(userID int) ([]model.TaskTemplate, error) {
+	templates, err := s.templateRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+
+	if templates == nil {
+		templates = []model.TaskTemplate{}
+	}
+
+	return templates, nil
+}
+
+// GetTemplateByID mengambil template milik user
+func (s *templateService) GetTemplateByID(templateID, userID int) (*model.TaskTemplate, error) {
+	return s.getOwnedTemplate(templateID, userID)
+}
+
+// UpdateTemplate mengganti seluruh isi template milik user
+func (s *templateService) UpdateTemplate(templateID, userID int, req *model.TaskTemplateRequest) (*model.TaskTemplate, error) {
+	template, err := s.getOwnedTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(template); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+
+	updatedTemplate, err := s.templateRepo.GetByID(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated template: %w", err)
+	}
+
+	return updatedTemplate, nil
+}
+
+// DeleteTemplate menghapus template milik user, task yang sudah dibuat dari
+// template tidak terpengaruh
+func (s *templateService) DeleteTemplate(templateID, userID int) error {
+	if _, err := s.getOwnedTemplate(templateID, userID); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(templateID); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return nil
+}
+
+// InstantiateTemplate membuat task beserta subtask dari template dalam satu
+// transaksi, sehingga kegagalan di tengah jalan tidak meninggalkan task
+// setengah jadi. Placeholder diisi sebelum transaksi dimulai.
+func (s *templateService) InstantiateTemplate(templateID, userID int, req *model.TemplateInstantiateRequest) (*model.TemplateInstantiateResponse, error) {
+	template, err := s.getOwnedTemplate(templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := s.placeholderValues(userID, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &model.TaskCreateRequest{
+		Status:    template.Status,
+		Priority:  template.Priority,
+		ProjectID: req.ProjectID,
+		LabelIDs:  template.LabelIDs,
+	}
+	if root.Title, err = renderTitle(template.Title, values); err != nil {
+		return nil, err
+	}
+	if root.Description, err = renderOptional(template.Description, values); err != nil {
+		return nil, err
+	}
+
+	subtasks := make([]model.TaskCreateRequest, len(template.Subtasks))
+	for i, subtask := range template.Subtasks {
+		if subtasks[i].Title, err = renderTitle(subtask.Title, values); err != nil {
+			return nil, err
+		}
+		if subtasks[i].Description, err = renderOptional(subtask.Description, values); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &model.TemplateInstantiateResponse{Subtasks: []model.Task{}}
+	err = s.taskService.WithTransaction(func(tx TaskService) error {
+		task, err := tx.CreateTask(userID, root)
+		if err != nil {
+			return err
+		}
+
+		for i := range subtasks {
+			subtask, err := tx.CreateSubtask(task.ID, userID, &subtasks[i], false)
+			if err != nil {
+				return err
+			}
+			resp.Subtasks = append(resp.Subtasks, *subtask)
+		}
+
+		// Diambil ulang agar rollup subtask ikut terisi
+		task, err = tx.GetTaskByID(task.ID, userID, false)
+		if err != nil {
+			return err
+		}
+		resp.Task = *task
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// applyRequest menyalin isi request ke template setelah label divalidasi
+func (s *templateService) applyRequest(template *model.TaskTemplate, req *model.TaskTemplateRequest) error {
+	if err := checkLabelsOwnedBy(s.labelRepo, template.UserID, req.LabelIDs); err != nil {
+		return err
+	}
+
+	template.Name = req.Name
+	template.Title = req.Title
+	template.Description = req.Description
+	template.Status = req.Status
+	template.Priority = req.Priority
+	template.LabelIDs = req.LabelIDs
+	template.Subtasks = req.Subtasks
+	return nil
+}
+
+// getOwnedTemplate mengambil template dan memastikan template milik user
+func (s *templateService) getOwnedTemplate(templateID, userID int) (*model.TaskTemplate, error) {
+	template, err := s.templateRepo.GetByID(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	if template == nil {
+		return nil, errors.New(model.ErrTemplateNotFound)
+	}
+	if template.UserID != userID {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	return template, nil
+}
+
+// placeholderValues menggabungkan placeholder bawaan ({{date}} berisi tanggal
+// hari ini dan {{name}} berisi nama user) dengan variables dari request
+func (s *templateService) placeholderValues(userID int, variables map[string]string) (map[string]string, error) {
+	values := map[string]string{
+		"date": time.Now().Format("2006-01-02"),
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user != nil {
+		values["name"] = user.Name
+	}
+
+	for key, value := range variables {
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// renderTemplate mengganti semua placeholder pada text. Placeholder tanpa
+// nilai menghasilkan error agar task tidak dibuat dengan "{{...}}" tersisa.
+func renderTemplate(text string, values map[string]string) (string, error) {
+	var missing bool
+	rendered := templatePlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		key := templatePlaceholder.FindStringSubmatch(match)[1]
+		value, ok := values[key]
+		if !ok {
+			missing = true
+		}
+		return value
+	})
+	if missing {
+		return "", errors.New(model.ErrTemplateVariableMissing)
+	}
+
+	return rendered, nil
+}
+
+// renderTitle seperti renderTemplate untuk judul task. Hasilnya divalidasi
+// ulang karena nilai placeholder bisa membuat judul kosong atau terlalu panjang.
+func renderTitle(text string, values map[string]string) (string, error) {
+	rendered, err := renderTemplate(text, values)
+	if err != nil {
+		return "", err
+	}
+	if rendered == "" || utf8.RuneCountInString(rendered) > maxTaskTitleLength {
+		return "", errors.New(model.ErrTemplateTitleInvalid)
+	}
+	return rendered, nil
+}
+
+// renderOptional seperti renderTemplate untuk field opsional
+func renderOptional(text *string, values map[string]string) (*string, error) {
+	if text == nil {
+		return nil, nil
+	}
+	rendered, err := renderTemplate(*text, values)
+	if err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type WorkflowService interface {
+	CreateWorkflow(req *model.WorkflowRequest) (*model.Workflow, error)
+	GetWorkflowByID(workflowID int) (*model.Workflow, error)
+	GetAllWorkflows() ([]model.Workflow, error)
+	UpdateWorkflow(workflowID int, req *model.WorkflowRequest) (*model.Workflow, error)
+	DeleteWorkflow(workflowID int) error
+	RefreshKnownStatuses() error
+}
+
+type workflowService struct {
+	workflowRepo repository.WorkflowRepository
+}
+
+func NewWorkflowService(workflowRepo repository.WorkflowRepository) WorkflowService {
+	return &workflowService{
+		workflowRepo: workflowRepo,
+	}
+}
+
+// CreateWorkflow membuat workflow baru
+func (s *workflowService) CreateWorkflow(req *model.WorkflowRequest) (*model.Workflow, error) {
+	if !validWorkflow(req) {
+		return nil, errors.New(model.ErrInvalidWorkflow)
+	}
+
+	workflow := &model.Workflow{
+		Name:        req.Name,
+		Statuses:    req.Statuses,
+		Transitions: req.Transitions,
+	}
+
+	if err := s.workflowRepo.Create(workflow); err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	if err := s.RefreshKnownStatuses(); err != nil {
+		return nil, err
+	}
+
+	return s.GetWorkflowByID(workflow.ID)
+}
+
+// GetWorkflowByID mengambil workflow berdasarkan ID
+func (s *workflowService) GetWorkflowByID(workflowID int) (*model.Workflow, error) {
+	workflow, err := s.workflowRepo.GetByID(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+	if workflow == nil {
+		return nil, errors.New(model.ErrWorkflowNotFound)
+	}
+
+	return workflow, nil
+}
+
+// GetAllWorkflows mengambil semua workflow
+func (s *workflowService) GetAllWorkflows() ([]model.Workflow, error) {
+	workflows, err := s.workflowRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+
+	if workflows == nil {
+		workflows = []model.Workflow{}
+	}
+
+	return workflows, nil
+}
+
+// UpdateWorkflow mengganti seluruh status dan transisi workflow. Task dengan
+// status yang dihapus tetap tersimpan dan boleh dipindah ke status mana pun.
+func (s *workflowService) UpdateWorkflow(workflowID int, req *model.WorkflowRequest) (*model.Workflow, error) {
+	workflow, err := s.GetWorkflowByID(workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validWorkflow(req) {
+		return nil, errors.New(model.ErrInvalidWorkflow)
+	}
+
+	workflow.Name = req.Name
+	workflow.Statuses = req.Statuses
+	workflow.Transitions = req.Transitions
+
+	if err := s.workflowRepo.Update(workflow); err != nil {
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+
+	if err := s.RefreshKnownStatuses(); err != nil {
+		return nil, err
+	}
+
+	return s.GetWorkflowByID(workflowID)
+}
+
+// DeleteWorkflow menghapus workflow selain workflow default
+func (s *workflowService) DeleteWorkflow(workflowID int) error {
+	workflow, err := s.GetWorkflowByID(workflowID)
+	if err != nil {
+		return err
+	}
+	if workflow.IsDefault {
+		return errors.New(model.ErrDefaultWorkflow)
+	}
+
+	if err := s.workflowRepo.Delete(workflowID); err != nil {
+		return fmt.Errorf("failed to delete workflow: %w", err)
+	}
+
+	return s.RefreshKnownStatuses()
+}
+
+// RefreshKnownStatuses memperbarui daftar status yang diterima validator
+// dari semua workflow. Dipanggil saat startup dan setiap workflow berubah.
+func (s *workflowService) RefreshKnownStatuses() error {
+	workflows, err := s.workflowRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get workflows: %w", err)
+	}
+
+	statuses := append([]model.TaskStatus{}, model.DefaultWorkflowStatuses...)
+	for _, workflow := range workflows {
+		for _, status := range workflow.Statuses {
+			statuses = append(statuses, status.Key)
+		}
+	}
+	model.SetKnownTaskStatuses(statuses)
+
+	return nil
+}
+
+// validWorkflow memastikan key status unik, tepat satu status awal, dan
+// transisi hanya menghubungkan dua status berbeda dalam workflow yang sama
+func validWorkflow(req *model.WorkflowRequest) bool {
+	statuses := make(map[model.TaskStatus]bool, len(req.Statuses))
+	initial := 0
+	for _, status := range req.Statuses {
+		if statuses[status.Key] {
+			return false
+		}
+		statuses[status.Key] = true
+		if status.IsInitial {
+			initial++
+		}
+	}
+	if initial != 1 {
+		return false
+	}
+
+	seen := make(map[model.WorkflowTransition]bool, len(req.Transitions))
+	for _, transition := range req.Transitions {
+		if !statuses[transition.From] || !statuses[transition.To] || transition.From == transition.To || seen[transition] {
+			return false
+		}
+		seen[transition] = true
+	}
+
+	return true
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+)
+
+type WorklogService interface {
+	StartTimer(taskID, userID int, req *model.TimerStartRequest, isAdmin bool) (*model.Worklog, error)
+	StopTimer(taskID, userID int, isAdmin bool) (*model.Worklog, error)
+	GetRunningTimer(userID int) (*model.Worklog, error)
+	CreateWorklog(taskID, userID int, req *model.WorklogCreateRequest, isAdmin bool) (*model.Worklog, error)
+	GetTaskWorklogs(taskID, userID int, period model.WorklogRange, isAdmin bool) (*model.TaskWorklogsResponse, error)
+	DeleteWorklog(taskID, worklogID, userID int, isAdmin bool) error
+	GetUserSummary(targetUserID, userID int, period model.WorklogRange, isAdmin bool) (*model.WorklogSummary, error)
+}
+
+type worklogService struct {
+	worklogRepo repository.WorklogRepository
+	taskService TaskService
+}
+
+// NewWorklogService membuat instance WorklogService. Akses ke worklog
+// mengikuti authorization task melalui TaskService.GetTaskByID.
+func NewWorklogService(worklogRepo repository.WorklogRepository, taskService TaskService) WorklogService {
+	return &worklogService{
+		worklogRepo: worklogRepo,
+		taskService: taskService,
+	}
+}
+
+// StartTimer memulai timer user pada task. User hanya boleh punya satu
+// timer berjalan, timer sebelumnya harus dihentikan lebih dulu.
+func (s *worklogService) StartTimer(taskID, userID int, req *model.TimerStartRequest, isAdmin bool) (*model.Worklog, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	running, err := s.worklogRepo.GetRunning(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+	if running != nil {
+		return nil, errors.New(model.ErrTimerRunning)
+	}
+
+	worklog := &model.Worklog{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: time.Now().Truncate(time.Second),
+		Note:      req.Note,
+	}
+	if err := s.worklogRepo.Create(worklog); err != nil {
+		if errors.Is(err, repository.ErrTimerRunning) {
+			return nil, errors.New(model.ErrTimerRunning)
+		}
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	return s.getWorklog(worklog.ID)
+}
+
+// StopTimer menghentikan timer user yang berjalan pada task. Timer milik
+// user sendiri selalu boleh dihentikan tanpa mengecek task, sehingga timer
+// pada task yang sudah masuk trash tidak tertahan berjalan.
+func (s *worklogService) StopTimer(taskID, userID int, isAdmin bool) (*model.Worklog, error) {
+	running, err := s.worklogRepo.GetRunning(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+	if running == nil || running.TaskID != taskID {
+		// Task tetap dicek agar error not found dan forbidden konsisten
+		if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+			return nil, err
+		}
+		return nil, errors.New(model.ErrTimerNotRunning)
+	}
+
+	if err := s.worklogRepo.Stop(running.ID, time.Now().Truncate(time.Second)); err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return s.getWorklog(running.ID)
+}
+
+// GetRunningTimer mengambil timer user yang sedang berjalan
+func (s *worklogService) GetRunningTimer(userID int) (*model.Worklog, error) {
+	running, err := s.worklogRepo.GetRunning(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+	if running == nil {
+		return nil, errors.New(model.ErrTimerNotRunning)
+	}
+
+	return running, nil
+}
+
+// CreateWorklog mencatat waktu kerja secara manual
+func (s *worklogService) CreateWorklog(taskID, userID int, req *model.WorklogCreateRequest, isAdmin bool) (*model.Worklog, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	if !req.EndedAt.After(req.StartedAt) || req.EndedAt.After(time.Now()) {
+		return nil, errors.New(model.ErrInvalidWorklogRange)
+	}
+
+	endedAt := req.EndedAt
+	worklog := &model.Worklog{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: req.StartedAt,
+		EndedAt:   &endedAt,
+		Note:      req.Note,
+	}
+	if err := s.worklogRepo.Create(worklog); err != nil {
+		return nil, fmt.Errorf("failed to create worklog: %w", err)
+	}
+
+	return s.getWorklog(worklog.ID)
+}
+
+// GetTaskWorklogs mengambil worklog pada task beserta total per user
+func (s *worklogService) GetTaskWorklogs(taskID, userID int, period model.WorklogRange, isAdmin bool) (*model.TaskWorklogsResponse, error) {
+	if _, err := s.taskService.GetTaskByID(taskID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+
+	worklogs, err := s.worklogRepo.GetByTaskID(taskID, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worklogs: %w", err)
+	}
+
+	users, err := s.worklogRepo.SumByUser(taskID, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum worklogs: %w", err)
+	}
+
+	resp := &model.TaskWorklogsResponse{
+		Worklogs: worklogs,
+		Users:    users,
+	}
+	for _, user := range users {
+		resp.TotalSeconds += user.Seconds
+	}
+
+	return resp, nil
+}
+
+// DeleteWorklog menghapus worklog. Boleh dilakukan oleh pemilik worklog,
+// pemilik task, atau admin.
+func (s *worklogService) DeleteWorklog(taskID, worklogID, userID int, isAdmin bool) error {
+	task, err := s.taskService.GetTaskByID(taskID, userID, isAdmin)
+	if err != nil {
+		return err
+	}
+
+	worklog, err := s.worklogRepo.GetByID(worklogID)
+	if err != nil {
+		return fmt.Errorf("failed to get worklog: %w", err)
+	}
+	if worklog == nil || worklog.TaskID != taskID {
+		return errors.New(model.ErrWorklogNotFound)
+	}
+
+	if worklog.UserID != userID && !canManageTask(task, userID, isAdmin) {
+		return errors.New(model.ErrForbidden)
+	}
+
+	if err := s.worklogRepo.Delete(worklogID); err != nil {
+		return fmt.Errorf("failed to delete worklog: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserSummary menghitung total waktu user per task. User biasa hanya
+// boleh melihat ringkasan miliknya sendiri.
+func (s *worklogService) GetUserSummary(targetUserID, userID int, period model.WorklogRange, isAdmin bool) (*model.WorklogSummary, error) {
+	if targetUserID != userID && !isAdmin {
+		return nil, errors.New(model.ErrForbidden)
+	}
+
+	tasks, err := s.worklogRepo.SumByTask(targetUserID, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum worklogs: %w", err)
+	}
+
+	summary := &model.WorklogSummary{
+		UserID: targetUserID,
+		From:   period.From,
+		To:     period.To,
+		Tasks:  tasks,
+	}
+	for _, task := range tasks {
+		summary.TotalSeconds += task.Seconds
+	}
+
+	return summary, nil
+}
+
+// getWorklog mengambil worklog yang baru dibuat atau diubah
+func (s *worklogService) getWorklog(id int) (*model.Worklog, error) {
+	worklog, err := s.worklogRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worklog: %w", err)
+	}
+	if worklog == nil {
+		return nil, errors.New(model.ErrWorklogNotFound)
+	}
+
+	return worklog, nil
+}
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    DROP INDEX idx_due_at,
+    DROP COLUMN reminded_at,
+    DROP COLUMN due_at,
+    DROP COLUMN start_at;
@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN start_at TIMESTAMP NULL DEFAULT NULL AFTER status,
+    ADD COLUMN due_at TIMESTAMP NULL DEFAULT NULL AFTER start_at,
+    ADD COLUMN reminded_at TIMESTAMP NULL DEFAULT NULL AFTER due_at, -- NULL jika reminder belum dikirim
+
+    ADD INDEX idx_due_at (due_at);
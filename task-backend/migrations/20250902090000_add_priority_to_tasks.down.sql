@@ -0,0 +1,3 @@
+ALTER TABLE tasks
+    DROP INDEX idx_priority_due_at,
+    DROP COLUMN priority;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+    ADD COLUMN priority ENUM('low', 'medium', 'high', 'urgent') NOT NULL DEFAULT 'medium' AFTER status,
+
+    ADD INDEX idx_priority_due_at (priority, due_at);
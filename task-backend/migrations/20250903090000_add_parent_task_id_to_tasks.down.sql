@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+    DROP FOREIGN KEY fk_tasks_parent,
+    DROP INDEX idx_parent_task_id,
+    DROP COLUMN parent_task_id;
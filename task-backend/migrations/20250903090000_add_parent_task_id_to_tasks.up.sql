@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN parent_task_id INT NULL DEFAULT NULL AFTER user_id, -- NULL untuk task root
+
+    ADD CONSTRAINT fk_tasks_parent FOREIGN KEY (parent_task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    ADD INDEX idx_parent_task_id (parent_task_id);
@@ -0,0 +1,21 @@
+CREATE TABLE labels (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color CHAR(7) NOT NULL, -- hex color, contoh: #ff8800
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uniq_user_label_name (user_id, name)
+);
+
+CREATE TABLE task_labels (
+    task_id INT NOT NULL,
+    label_id INT NOT NULL,
+
+    PRIMARY KEY (task_id, label_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE,
+    INDEX idx_label_id (label_id)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    DROP FOREIGN KEY fk_tasks_project,
+    DROP INDEX idx_project_id,
+    DROP COLUMN project_id;
+
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+    DROP FOREIGN KEY fk_tasks_assignee,
+    DROP INDEX idx_assignee_id,
+    DROP COLUMN assignee_id;
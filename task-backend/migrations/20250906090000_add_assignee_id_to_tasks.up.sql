@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN assignee_id INT NULL DEFAULT NULL AFTER project_id, -- NULL jika belum di-assign
+
+    ADD CONSTRAINT fk_tasks_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL,
+    ADD INDEX idx_assignee_id (assignee_id);
@@ -0,0 +1,14 @@
+CREATE TABLE task_comments (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    task_id INT NOT NULL,
+    user_id INT NOT NULL,
+    parent_id INT NULL DEFAULT NULL, -- komentar root untuk reply, NULL jika komentar root
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT NULL, -- diisi saat komentar diedit
+
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES task_comments(id) ON DELETE CASCADE,
+    INDEX idx_task_parent_created (task_id, parent_id, created_at)
+);
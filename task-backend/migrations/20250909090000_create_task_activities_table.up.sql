@@ -0,0 +1,13 @@
+CREATE TABLE task_activities (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    task_id INT NOT NULL, -- tanpa foreign key agar riwayat tetap ada setelah task dihapus
+    user_id INT NULL,
+    action ENUM('created', 'updated', 'deleted') NOT NULL,
+    field VARCHAR(50) NULL,
+    old_value TEXT NULL,
+    new_value TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_task_created (task_id, created_at)
+);
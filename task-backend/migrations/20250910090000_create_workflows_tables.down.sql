@@ -0,0 +1,12 @@
+UPDATE tasks SET status = 'pending' WHERE status NOT IN ('pending', 'in_progress', 'completed');
+
+ALTER TABLE tasks
+    MODIFY COLUMN status ENUM('pending', 'in_progress', 'completed') DEFAULT 'pending';
+
+ALTER TABLE projects
+    DROP FOREIGN KEY fk_projects_workflow,
+    DROP COLUMN workflow_id;
+
+DROP TABLE IF EXISTS workflow_transitions;
+DROP TABLE IF EXISTS workflow_statuses;
+DROP TABLE IF EXISTS workflows;
@@ -0,0 +1,55 @@
+CREATE TABLE workflows (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE workflow_statuses (
+    workflow_id INT NOT NULL,
+    status VARCHAR(30) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    is_initial BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (workflow_id, status),
+    FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE CASCADE
+);
+
+CREATE TABLE workflow_transitions (
+    workflow_id INT NOT NULL,
+    from_status VARCHAR(30) NOT NULL,
+    to_status VARCHAR(30) NOT NULL,
+
+    PRIMARY KEY (workflow_id, from_status, to_status),
+    FOREIGN KEY (workflow_id, from_status) REFERENCES workflow_statuses(workflow_id, status) ON DELETE CASCADE,
+    FOREIGN KEY (workflow_id, to_status) REFERENCES workflow_statuses(workflow_id, status) ON DELETE CASCADE
+);
+
+-- Workflow default mengikuti perilaku sebelumnya: tiga status, bebas berpindah
+INSERT INTO workflows (id, name, is_default) VALUES (1, 'Default', TRUE);
+
+INSERT INTO workflow_statuses (workflow_id, status, name, is_initial, position) VALUES
+    (1, 'pending', 'Pending', TRUE, 0),
+    (1, 'in_progress', 'In Progress', FALSE, 1),
+    (1, 'completed', 'Completed', FALSE, 2);
+
+INSERT INTO workflow_transitions (workflow_id, from_status, to_status) VALUES
+    (1, 'pending', 'in_progress'),
+    (1, 'pending', 'completed'),
+    (1, 'in_progress', 'pending'),
+    (1, 'in_progress', 'completed'),
+    (1, 'completed', 'pending'),
+    (1, 'completed', 'in_progress');
+
+ALTER TABLE projects
+    ADD COLUMN workflow_id INT NULL DEFAULT NULL AFTER description, -- NULL berarti memakai workflow default
+
+    ADD CONSTRAINT fk_projects_workflow FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE SET NULL;
+
+-- Status tidak lagi dibatasi ENUM, validasi dilakukan terhadap workflow
+UPDATE tasks SET status = 'pending' WHERE status IS NULL;
+
+ALTER TABLE tasks
+    MODIFY COLUMN status VARCHAR(30) NOT NULL DEFAULT 'pending';
@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+    DROP FOREIGN KEY fk_tasks_series,
+    DROP INDEX uniq_series_occurrence,
+    DROP COLUMN occurrence_at,
+    DROP COLUMN series_id;
+
+DROP TABLE IF EXISTS task_series;
@@ -0,0 +1,17 @@
+CREATE TABLE task_series (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    rrule VARCHAR(500) NOT NULL, -- RRULE RFC 5545 tanpa DTSTART
+    start_at TIMESTAMP NOT NULL, -- DTSTART, due date occurrence pertama
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE tasks
+    ADD COLUMN series_id INT NULL DEFAULT NULL AFTER assignee_id,
+    ADD COLUMN occurrence_at TIMESTAMP NULL DEFAULT NULL AFTER series_id,
+
+    ADD CONSTRAINT fk_tasks_series FOREIGN KEY (series_id) REFERENCES task_series(id) ON DELETE SET NULL,
+    ADD UNIQUE KEY uniq_series_occurrence (series_id, occurrence_at);
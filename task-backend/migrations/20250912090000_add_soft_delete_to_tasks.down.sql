@@ -0,0 +1,10 @@
+DELETE FROM task_activities WHERE action = 'restored';
+
+ALTER TABLE task_activities
+    MODIFY action ENUM('created', 'updated', 'deleted') NOT NULL;
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE tasks
+    DROP INDEX idx_deleted_at,
+    DROP COLUMN deleted_at;
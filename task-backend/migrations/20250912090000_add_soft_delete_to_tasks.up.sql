@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER reminded_at,
+    ADD INDEX idx_deleted_at (deleted_at);
+
+ALTER TABLE task_activities
+    MODIFY action ENUM('created', 'updated', 'deleted', 'restored') NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE task_comments
+    DROP INDEX ft_body;
+ALTER TABLE tasks
+    DROP INDEX ft_title_description;
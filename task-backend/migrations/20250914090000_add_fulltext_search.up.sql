@@ -0,0 +1,5 @@
+-- Index FULLTEXT untuk pencarian task (MATCH ... AGAINST IN BOOLEAN MODE)
+ALTER TABLE tasks
+    ADD FULLTEXT INDEX ft_title_description (title, description);
+ALTER TABLE task_comments
+    ADD FULLTEXT INDEX ft_body (body);
@@ -0,0 +1,10 @@
+CREATE TABLE task_dependencies (
+    task_id INT NOT NULL, -- task yang terblokir
+    blocker_id INT NOT NULL, -- task yang harus selesai lebih dulu
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (task_id, blocker_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    INDEX idx_blocker_id (blocker_id)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE task_worklogs (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    user_id INT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NULL, -- NULL berarti timer masih berjalan
+    note VARCHAR(1000) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Hanya terisi untuk timer yang berjalan, sehingga unique key menjamin
+    -- satu timer berjalan per user
+    running_user_id INT GENERATED ALWAYS AS (IF(ended_at IS NULL, user_id, NULL)) STORED,
+
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uniq_running_timer (running_user_id),
+    INDEX idx_task_started_at (task_id, started_at),
+    INDEX idx_user_started_at (user_id, started_at)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+    DROP INDEX idx_status_board_rank,
+    DROP INDEX uniq_user_board_rank,
+    DROP COLUMN board_rank;
@@ -0,0 +1,16 @@
+-- Rank dibandingkan per byte (lihat pkg/rank) sehingga memakai collation biner
+ALTER TABLE tasks
+    ADD COLUMN board_rank VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NULL AFTER version;
+
+-- Task yang sudah ada diurutkan sesuai ID. Head rank menentukan panjang
+-- bagian integer ('a' untuk 1 digit, 'b' untuk 2 digit, dst), sehingga
+-- lebarnya diambil dari ID terbesar agar tidak ada ID yang terpotong
+SET @rank_width = (SELECT COALESCE(LENGTH(MAX(id)), 1) FROM tasks);
+UPDATE tasks SET board_rank = CONCAT(CHAR(ORD('a') + @rank_width - 1 USING ascii), LPAD(id, @rank_width, '0'));
+
+-- Rank unik per pemilik task, sehingga membuat atau memindahkan task hanya
+-- mengunci rank milik user yang sama
+ALTER TABLE tasks
+    MODIFY COLUMN board_rank VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL,
+    ADD UNIQUE INDEX uniq_user_board_rank (user_id, board_rank),
+    ADD INDEX idx_status_board_rank (status, board_rank);
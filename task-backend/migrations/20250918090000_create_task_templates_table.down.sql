@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_template_labels;
+DROP TABLE IF EXISTS task_template_subtasks;
+DROP TABLE IF EXISTS task_templates;
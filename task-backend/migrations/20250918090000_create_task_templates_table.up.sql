@@ -0,0 +1,33 @@
+CREATE TABLE task_templates (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    status VARCHAR(30) NULL, -- NULL berarti status awal workflow
+    priority ENUM('low', 'medium', 'high', 'urgent') NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_name (user_id, name)
+);
+
+CREATE TABLE task_template_subtasks (
+    template_id INT NOT NULL,
+    position INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+
+    PRIMARY KEY (template_id, position),
+    FOREIGN KEY (template_id) REFERENCES task_templates(id) ON DELETE CASCADE
+);
+
+CREATE TABLE task_template_labels (
+    template_id INT NOT NULL,
+    label_id INT NOT NULL,
+
+    PRIMARY KEY (template_id, label_id),
+    FOREIGN KEY (template_id) REFERENCES task_templates(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+CREATE TABLE calendar_tokens (
+    user_id INT PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL, -- SHA-256 hex dari token pada URL feed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE KEY uniq_token_hash (token_hash)
+);
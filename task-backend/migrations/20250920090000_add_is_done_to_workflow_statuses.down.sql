@@ -0,0 +1,2 @@
+ALTER TABLE workflow_statuses
+    DROP COLUMN is_done;
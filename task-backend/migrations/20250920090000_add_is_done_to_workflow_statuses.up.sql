@@ -0,0 +1,7 @@
+-- Status dengan is_done dianggap selesai: dipakai untuk progress subtasks,
+-- overdue, reminder, blocker, dan occurrence berikutnya task berulang
+ALTER TABLE workflow_statuses
+    ADD COLUMN is_done BOOLEAN NOT NULL DEFAULT FALSE AFTER is_initial;
+
+-- Sebelumnya hanya status 'completed' yang dianggap selesai
+UPDATE workflow_statuses SET is_done = TRUE WHERE status = 'completed';
@@ -0,0 +1,488 @@
+package filterql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Batas ukuran ekspresi agar query yang dihasilkan tetap wajar
+const (
+	MaxLength   = 1000
+	MaxDepth    = 10
+	MaxInValues = 100
+)
+
+// Error menunjuk token yang menyebabkan ekspresi filter atau sort tidak valid.
+// Pos adalah posisi karakter (dimulai dari 0) token tersebut di input.
+type Error struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at end of expression", e.Message)
+	}
+	return fmt.Sprintf("%s at position %d near %q", e.Message, e.Pos, e.Token)
+}
+
+// FieldType menentukan operator dan format nilai yang boleh dipakai field
+type FieldType int
+
+const (
+	String FieldType = iota // =, !=, in, ~ (contains)
+	Enum                    // =, !=, in dengan nilai dari Field.Values
+	Int                     // =, !=, <, <=, >, >=, in
+	Time                    // =, !=, <, <=, >, >= dengan RFC3339 atau YYYY-MM-DD
+)
+
+// Field adalah field yang boleh dipakai dalam ekspresi filter. Field yang
+// Nullable juga mendukung "is null" dan "is not null".
+type Field struct {
+	Type     FieldType
+	Values   []string // nilai yang valid untuk Enum
+	Nullable bool
+}
+
+// Fields adalah whitelist field berdasarkan nama di ekspresi
+type Fields map[string]Field
+
+// Operator perbandingan
+const (
+	OpEq       = "="
+	OpNe       = "!="
+	OpLt       = "<"
+	OpLte      = "<="
+	OpGt       = ">"
+	OpGte      = ">="
+	OpContains = "~"
+	OpIn       = "in"
+	OpNotIn    = "not in"
+	OpIsNull   = "is null"
+	OpNotNull  = "is not null"
+)
+
+var fieldOperators = map[FieldType][]string{
+	String: {OpEq, OpNe, OpIn, OpNotIn, OpContains},
+	Enum:   {OpEq, OpNe, OpIn, OpNotIn},
+	Int:    {OpEq, OpNe, OpLt, OpLte, OpGt, OpGte, OpIn, OpNotIn},
+	Time:   {OpEq, OpNe, OpLt, OpLte, OpGt, OpGte},
+}
+
+// Node adalah hasil parsing ekspresi filter: And, Or, Not, atau Condition
+type Node interface {
+	node()
+}
+
+// And benar jika semua Nodes benar
+type And struct{ Nodes []Node }
+
+// Or benar jika salah satu Nodes benar
+type Or struct{ Nodes []Node }
+
+// Not membalik hasil Node
+type Not struct{ Node Node }
+
+// Condition membandingkan satu field dengan nilai yang sudah dikonversi
+// sesuai tipe field (string, int, atau time.Time)
+type Condition struct {
+	Field    string
+	Def      Field
+	Operator string
+	Values   []interface{}
+}
+
+func (And) node()       {}
+func (Or) node()        {}
+func (Not) node()       {}
+func (Condition) node() {}
+
+// Parse membaca ekspresi filter seperti
+//
+//	status in (pending, in_progress) and created_at > 2026-01-01
+//
+// dan memvalidasi setiap field, operator, dan nilai terhadap fields.
+// Kata kunci and, or, not, in, is, dan null tidak case sensitive.
+func Parse(input string, fields Fields) (Node, error) {
+	if len(input) > MaxLength {
+		return nil, &Error{Pos: MaxLength, Token: input[MaxLength:min(len(input), MaxLength+10)], Message: fmt.Sprintf("expression longer than %d characters", MaxLength)}
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok, "unexpected token")
+	}
+
+	return node, nil
+}
+
+// SortField adalah satu kolom pengurutan, Desc untuk awalan "-"
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort membaca daftar sort dipisah koma seperti "-updated_at,title".
+// Setiap field harus ada di fields dan tidak boleh diulang.
+func ParseSort(input string, fields map[string]bool) ([]SortField, error) {
+	var result []SortField
+	seen := make(map[string]bool)
+
+	pos := 0
+	for _, part := range strings.Split(input, ",") {
+		start := pos + len(part) - len(strings.TrimLeft(part, " "))
+		pos += len(part) + 1
+
+		name := strings.TrimSpace(part)
+		sort := SortField{Field: name}
+		if strings.HasPrefix(name, "-") {
+			sort = SortField{Field: name[1:], Desc: true}
+		}
+
+		switch {
+		case name == "":
+			return nil, &Error{Pos: start, Token: ",", Message: "empty sort field"}
+		case !fields[sort.Field]:
+			return nil, &Error{Pos: start, Token: name, Message: "unknown sort field"}
+		case seen[sort.Field]:
+			return nil, &Error{Pos: start, Token: name, Message: "duplicate sort field"}
+		}
+
+		seen[sort.Field] = true
+		result = append(result, sort)
+	}
+
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+	text  string // teks asli untuk pesan error
+}
+
+// keyword mengecek apakah token adalah kata kunci tertentu
+func (t token) keyword(word string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.value, word)
+}
+
+// tokenize memecah input menjadi token. Kata adalah deretan karakter selain
+// spasi, tanda kurung, koma, kutip, dan operator sehingga tanggal seperti
+// 2026-01-01T10:00:00+07:00 terbaca sebagai satu kata.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i, text: ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: i, text: ","})
+			i++
+		case r == '\'' || r == '"':
+			var b strings.Builder
+			end := i + 1
+			for ; end < len(runes) && runes[end] != r; end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				b.WriteRune(runes[end])
+			}
+			if end >= len(runes) {
+				return nil, &Error{Pos: i, Token: string(runes[i:]), Message: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: b.String(), pos: i, text: string(runes[i : end+1])})
+			i = end + 1
+		case strings.ContainsRune("=!<>~", r):
+			end := i + 1
+			if end < len(runes) && (runes[end] == '=' && r != '=' && r != '~' || r == '<' && runes[end] == '>') {
+				end++
+			}
+			op := string(runes[i:end])
+			if op == "!" {
+				return nil, &Error{Pos: i, Token: op, Message: "unknown operator"}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: i, text: op})
+			i = end
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("(),'\"=!<>~", runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			tokens = append(tokens, token{kind: tokenWord, value: word, pos: i, text: word})
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields Fields
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) *Error {
+	return &Error{Pos: tok.pos, Token: tok.text, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseOr: and_expr ("or" and_expr)*
+func (p *parser) parseOr(depth int) (Node, error) {
+	if depth > MaxDepth {
+		return nil, p.errorf(p.peek(), "expression nested deeper than %d levels", MaxDepth)
+	}
+
+	var nodes []Node
+	for {
+		node, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		if !p.peek().keyword("or") {
+			break
+		}
+		p.next()
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return Or{Nodes: nodes}, nil
+}
+
+// parseAnd: unary ("and" unary)*
+func (p *parser) parseAnd(depth int) (Node, error) {
+	var nodes []Node
+	for {
+		node, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		if !p.peek().keyword("and") {
+			break
+		}
+		p.next()
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return And{Nodes: nodes}, nil
+}
+
+// parseUnary: "not" unary | "(" or_expr ")" | condition
+func (p *parser) parseUnary(depth int) (Node, error) {
+	tok := p.peek()
+	switch {
+	case tok.keyword("not"):
+		p.next()
+		node, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: node}, nil
+	case tok.kind == tokenLParen:
+		p.next()
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorf(closing, "expected )")
+		}
+		return node, nil
+	}
+
+	return p.parseCondition()
+}
+
+// parseCondition: field operator value | field ["not"] "in" "(" values ")" |
+// field "is" ["not"] "null"
+func (p *parser) parseCondition() (Node, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, p.errorf(fieldTok, "expected field name")
+	}
+	name := strings.ToLower(fieldTok.value)
+	def, ok := p.fields[name]
+	if !ok {
+		return nil, p.errorf(fieldTok, "unknown field")
+	}
+
+	cond := Condition{Field: name, Def: def}
+	opTok := p.next()
+
+	switch {
+	case opTok.kind == tokenOperator:
+		cond.Operator = opTok.value
+		if cond.Operator == "<>" {
+			cond.Operator = OpNe
+		}
+		if !allowed(def.Type, cond.Operator) {
+			return nil, p.errorf(opTok, "operator %s is not supported for field %s", cond.Operator, name)
+		}
+		valueTok := p.next()
+		value, err := p.parseValue(def, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = []interface{}{value}
+
+	case opTok.keyword("in"), opTok.keyword("not") && p.peek().keyword("in"):
+		cond.Operator = OpIn
+		if opTok.keyword("not") {
+			cond.Operator = OpNotIn
+			p.next()
+		}
+		if !allowed(def.Type, cond.Operator) {
+			return nil, p.errorf(opTok, "operator %s is not supported for field %s", cond.Operator, name)
+		}
+		values, err := p.parseList(def)
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = values
+
+	case opTok.keyword("is"):
+		cond.Operator = OpIsNull
+		if p.peek().keyword("not") {
+			cond.Operator = OpNotNull
+			p.next()
+		}
+		if nullTok := p.next(); !nullTok.keyword("null") {
+			return nil, p.errorf(nullTok, "expected null")
+		}
+		if !def.Nullable {
+			return nil, p.errorf(fieldTok, "field %s cannot be null", name)
+		}
+		return cond, nil
+
+	default:
+		return nil, p.errorf(opTok, "expected operator")
+	}
+
+	return cond, nil
+}
+
+// parseList: "(" value ("," value)* ")"
+func (p *parser) parseList(def Field) ([]interface{}, error) {
+	if open := p.next(); open.kind != tokenLParen {
+		return nil, p.errorf(open, "expected (")
+	}
+
+	var values []interface{}
+	for {
+		valueTok := p.next()
+		value, err := p.parseValue(def, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if len(values) > MaxInValues {
+			return nil, p.errorf(valueTok, "more than %d values in list", MaxInValues)
+		}
+
+		sep := p.next()
+		if sep.kind == tokenRParen {
+			return values, nil
+		}
+		if sep.kind != tokenComma {
+			return nil, p.errorf(sep, "expected , or )")
+		}
+	}
+}
+
+// parseValue mengonversi token nilai sesuai tipe field
+func (p *parser) parseValue(def Field, tok token) (interface{}, error) {
+	if tok.kind != tokenWord && tok.kind != tokenString {
+		return nil, p.errorf(tok, "expected value")
+	}
+
+	switch def.Type {
+	case Int:
+		n, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, p.errorf(tok, "expected integer")
+		}
+		return n, nil
+	case Time:
+		if t, err := time.Parse(time.RFC3339, tok.value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", tok.value)
+		if err != nil {
+			return nil, p.errorf(tok, "expected RFC3339 timestamp or YYYY-MM-DD date")
+		}
+		return t, nil
+	case Enum:
+		for _, value := range def.Values {
+			if strings.EqualFold(tok.value, value) {
+				return value, nil
+			}
+		}
+		return nil, p.errorf(tok, "expected one of: %s", strings.Join(def.Values, " "))
+	}
+
+	return tok.value, nil
+}
+
+func allowed(fieldType FieldType, operator string) bool {
+	for _, op := range fieldOperators[fieldType] {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
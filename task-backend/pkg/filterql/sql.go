@@ -0,0 +1,109 @@
+package filterql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Columns memetakan nama field di ekspresi ke kolom SQL. Hanya nilai dari
+// map ini yang masuk ke teks query, nilai dari user selalu menjadi argumen.
+type Columns map[string]string
+
+// SQL menyusun klausa WHERE berparameter dari node hasil Parse
+func SQL(node Node, columns Columns) (string, []interface{}, error) {
+	var args []interface{}
+	clause, err := compile(node, columns, &args)
+	if err != nil {
+		return "", nil, err
+	}
+	return clause, args, nil
+}
+
+// OrderBy menyusun klausa ORDER BY dari hasil ParseSort
+func OrderBy(sorts []SortField, columns Columns) (string, error) {
+	var parts []string
+	for _, sort := range sorts {
+		column, ok := columns[sort.Field]
+		if !ok {
+			return "", fmt.Errorf("filterql: no column for sort field %s", sort.Field)
+		}
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		parts = append(parts, column+" "+direction)
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+func compile(node Node, columns Columns, args *[]interface{}) (string, error) {
+	switch n := node.(type) {
+	case And:
+		return compileGroup(n.Nodes, " AND ", columns, args)
+	case Or:
+		return compileGroup(n.Nodes, " OR ", columns, args)
+	case Not:
+		inner, err := compile(n.Node, columns, args)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + inner, nil
+	case Condition:
+		return compileCondition(n, columns, args)
+	}
+	return "", fmt.Errorf("filterql: unknown node %T", node)
+}
+
+func compileGroup(nodes []Node, separator string, columns Columns, args *[]interface{}) (string, error) {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part, err := compile(node, columns, args)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	return "(" + strings.Join(parts, separator) + ")", nil
+}
+
+// compileCondition menerjemahkan satu kondisi. Untuk field nullable, != dan
+// "not in" juga mencocokkan NULL seperti yang diharapkan user.
+func compileCondition(c Condition, columns Columns, args *[]interface{}) (string, error) {
+	column, ok := columns[c.Field]
+	if !ok {
+		return "", fmt.Errorf("filterql: no column for field %s", c.Field)
+	}
+
+	switch c.Operator {
+	case OpIsNull:
+		return "(" + column + " IS NULL)", nil
+	case OpNotNull:
+		return "(" + column + " IS NOT NULL)", nil
+	case OpNe:
+		*args = append(*args, c.Values[0])
+		return "NOT (" + column + " <=> ?)", nil
+	case OpContains:
+		*args = append(*args, "%"+escapeLike(c.Values[0].(string))+"%")
+		return "(" + column + " LIKE ?)", nil
+	case OpIn, OpNotIn:
+		*args = append(*args, c.Values...)
+		list := strings.TrimSuffix(strings.Repeat("?, ", len(c.Values)), ", ")
+		if c.Operator == OpIn {
+			return "(" + column + " IN (" + list + "))", nil
+		}
+		if c.Def.Nullable {
+			return "(" + column + " IS NULL OR " + column + " NOT IN (" + list + "))", nil
+		}
+		return "(" + column + " NOT IN (" + list + "))", nil
+	case OpEq, OpLt, OpLte, OpGt, OpGte:
+		*args = append(*args, c.Values[0])
+		return "(" + column + " " + c.Operator + " ?)", nil
+	}
+
+	return "", fmt.Errorf("filterql: unknown operator %s", c.Operator)
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar ~ selalu berarti contains
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
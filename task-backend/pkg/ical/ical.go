@@ -0,0 +1,97 @@
+// Package ical menulis data iCalendar (RFC 5545). Setiap baris diakhiri
+// CRLF dan dilipat jika lebih dari 75 octet, nilai text di-escape sesuai
+// spesifikasi.
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType adalah media type untuk data iCalendar
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineLength adalah panjang maksimum satu baris dalam octet tanpa CRLF
+const maxLineLength = 75
+
+// textEscaper meng-escape karakter khusus pada nilai bertipe TEXT
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Writer menulis component dan property iCalendar. Error pertama disimpan
+// dan dikembalikan oleh Err, penulisan berikutnya diabaikan.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+// NewWriter membuat Writer yang menulis ke w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Begin membuka component, misalnya VCALENDAR atau VEVENT
+func (w *Writer) Begin(component string) {
+	w.line("BEGIN:" + component)
+}
+
+// End menutup component
+func (w *Writer) End(component string) {
+	w.line("END:" + component)
+}
+
+// Text menulis property bertipe TEXT, nilai di-escape
+func (w *Writer) Text(name, value string) {
+	w.line(name + ":" + textEscaper.Replace(value))
+}
+
+// TextList menulis property berisi beberapa nilai TEXT, misalnya CATEGORIES
+func (w *Writer) TextList(name string, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = textEscaper.Replace(value)
+	}
+	w.line(name + ":" + strings.Join(escaped, ","))
+}
+
+// Raw menulis property tanpa escape, untuk nilai seperti STATUS atau PRIORITY
+func (w *Writer) Raw(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// Time menulis property DATE-TIME dalam UTC
+func (w *Writer) Time(name string, t time.Time) {
+	w.line(name + ":" + t.UTC().Format("20060102T150405Z"))
+}
+
+// Err mengembalikan error pertama saat menulis
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// line menulis satu content line, dilipat dengan CRLF diikuti spasi tanpa
+// memotong karakter UTF-8
+func (w *Writer) line(content string) {
+	if w.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := maxLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// Spasi di awal baris lanjutan ikut dihitung
+		limit = maxLineLength - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+
+	_, w.err = io.WriteString(w.w, b.String())
+}
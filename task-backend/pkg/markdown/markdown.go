@@ -0,0 +1,87 @@
+// Package markdown merender Markdown (CommonMark dengan task list GFM)
+// menjadi HTML yang aman ditampilkan langsung oleh client. Raw HTML pada
+// source tidak pernah diteruskan dan hasil render selalu melewati Sanitize.
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"html"
+
+	"github.com/yuin/goldmark"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// ErrTaskItemNotFound dikembalikan jika tidak ada checkbox dengan index tersebut
+var ErrTaskItemNotFound = errors.New("markdown: task list item not found")
+
+// md tidak memakai html.WithUnsafe sehingga raw HTML dan URL berbahaya
+// dibuang oleh goldmark sebelum disanitasi
+var md = goldmark.New(goldmark.WithExtensions(extension.TaskList))
+
+// Render mengubah source Markdown menjadi HTML yang sudah disanitasi.
+// Checkbox task list dirender sebagai <input type="checkbox" disabled>
+// sesuai urutan kemunculannya, urutan yang sama dipakai ToggleTask.
+func Render(source string) string {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		// Tidak terjadi untuk bytes.Buffer, tetapi jangan sampai source mentah lolos
+		return "<p>" + html.EscapeString(source) + "</p>\n"
+	}
+	return Sanitize(buf.String())
+}
+
+// ToggleTask mengubah checkbox task list ke-index (mulai dari 0) pada
+// source menjadi checked atau unchecked. Bagian lain source tidak diubah.
+func ToggleTask(source string, index int, checked bool) (string, error) {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	offset := -1
+	count := 0
+	err := gast.Walk(doc, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		if !entering || n.Kind() != east.KindTaskCheckBox {
+			return gast.WalkContinue, nil
+		}
+		if count == index {
+			offset = checkBoxOffset(src, n)
+			return gast.WalkStop, nil
+		}
+		count++
+		return gast.WalkContinue, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if offset < 0 {
+		return "", ErrTaskItemNotFound
+	}
+
+	mark := byte(' ')
+	if checked {
+		mark = 'x'
+	}
+	if src[offset] == mark || (checked && src[offset] == 'X') {
+		return source, nil
+	}
+	src[offset] = mark
+	return string(src), nil
+}
+
+// checkBoxOffset mencari posisi karakter di dalam "[ ]" milik checkbox.
+// Checkbox selalu berada di awal baris pertama text block list item.
+func checkBoxOffset(src []byte, checkBox gast.Node) int {
+	lines := checkBox.Parent().Lines()
+	if lines.Len() == 0 {
+		return -1
+	}
+	segment := lines.At(0)
+	start := segment.Start + bytes.IndexByte(src[segment.Start:segment.Stop], '[')
+	if start < segment.Start || start+2 >= len(src) || src[start+2] != ']' {
+		return -1
+	}
+	return start + 1
+}
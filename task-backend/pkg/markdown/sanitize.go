@@ -0,0 +1,146 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// allowedTags adalah tag yang boleh muncul pada HTML hasil render beserta
+// atribut yang diizinkan untuk masing-masing tag
+var allowedTags = map[string]map[string]bool{
+	"p": {}, "br": {}, "hr": {}, "blockquote": {}, "pre": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"em": {}, "strong": {}, "ul": {}, "li": {},
+	"ol":    {"start": true},
+	"code":  {"class": true},
+	"a":     {"href": true, "title": true},
+	"img":   {"src": true, "alt": true, "title": true},
+	"input": {"type": true, "checked": true, "disabled": true},
+}
+
+// droppedContent adalah tag yang isinya ikut dibuang, bukan hanya tagnya
+var droppedContent = map[string]bool{"script": true, "style": true, "iframe": true, "object": true, "textarea": true}
+
+// languageClass adalah class yang dibuat goldmark untuk fenced code block
+var languageClass = regexp.MustCompile(`^language-[A-Za-z0-9_+#.-]+$`)
+
+// Sanitize membuang semua tag dan atribut di luar allowlist. Text di-escape
+// ulang, link hanya boleh http, https, mailto atau relatif, gambar hanya
+// http, https atau relatif, dan input hanya checkbox yang disabled. Comment
+// dan doctype selalu dibuang.
+func Sanitize(source string) string {
+	var b strings.Builder
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(source))
+	skipping := ""
+
+	for {
+		tt := tokenizer.Next()
+		if tt == nethtml.ErrorToken {
+			// io.EOF atau input yang tidak bisa dibaca lagi
+			return b.String()
+		}
+		token := tokenizer.Token()
+
+		if skipping != "" {
+			if tt == nethtml.EndTagToken && token.Data == skipping {
+				skipping = ""
+			}
+			continue
+		}
+
+		switch tt {
+		case nethtml.TextToken:
+			b.WriteString(html.EscapeString(token.Data))
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			if droppedContent[token.Data] && tt == nethtml.StartTagToken {
+				skipping = token.Data
+				continue
+			}
+			if attrs, ok := sanitizeAttrs(token); ok {
+				b.WriteString("<" + token.Data + attrs + ">")
+			}
+		case nethtml.EndTagToken:
+			if _, ok := allowedTags[token.Data]; ok && !isVoid(token.Data) {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+// sanitizeAttrs menyusun atribut yang diizinkan untuk token. ok false
+// berarti tag tidak diizinkan sama sekali.
+func sanitizeAttrs(token nethtml.Token) (string, bool) {
+	allowed, ok := allowedTags[token.Data]
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !allowed[attr.Key] {
+			continue
+		}
+		value := attr.Val
+		switch {
+		case token.Data == "a" && attr.Key == "href":
+			if !safeURL(value, "http", "https", "mailto") {
+				continue
+			}
+		case token.Data == "img" && attr.Key == "src":
+			if !safeURL(value, "http", "https") {
+				continue
+			}
+		case token.Data == "code" && attr.Key == "class":
+			if !languageClass.MatchString(value) {
+				continue
+			}
+		case token.Data == "input" && attr.Key == "type":
+			if value != "checkbox" {
+				return "", false
+			}
+		case token.Data == "input" && attr.Key == "disabled":
+			// Selalu ditambahkan di bawah
+			continue
+		}
+		b.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+	}
+
+	switch token.Data {
+	case "a":
+		b.WriteString(` rel="nofollow noopener noreferrer"`)
+	case "input":
+		if !strings.Contains(b.String(), ` type="checkbox"`) {
+			return "", false
+		}
+		b.WriteString(` disabled=""`)
+	}
+
+	return b.String(), true
+}
+
+// safeURL mengecek URL relatif atau dengan salah satu scheme yang diizinkan
+func safeURL(value string, schemes ...string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// Tanpa scheme tapi berisi ":" sebelum "/" bisa dibaca browser sebagai scheme
+		return u.Opaque == "" && !strings.Contains(strings.SplitN(value, "/", 2)[0], ":")
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVoid mengecek tag yang tidak punya closing tag
+func isVoid(tag string) bool {
+	return tag == "br" || tag == "hr" || tag == "img" || tag == "input"
+}
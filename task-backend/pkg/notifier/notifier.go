@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Reminder berisi data yang dikirim ke user saat task mendekati due date
+type Reminder struct {
+	TaskID    int
+	UserID    int
+	UserEmail string
+	UserName  string
+	Title     string
+	DueAt     time.Time
+}
+
+// Notifier mengirim reminder ke user. Implementasi harus aman dipanggil
+// dari goroutine scheduler.
+type Notifier interface {
+	Notify(reminder Reminder) error
+}
+
+// LogNotifier menulis reminder ke log, cocok untuk development
+type LogNotifier struct{}
+
+// NewLogNotifier membuat instance LogNotifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify menulis reminder ke log
+func (n *LogNotifier) Notify(reminder Reminder) error {
+	log.Printf("Reminder: task %d %q for user %d (%s) is due at %s",
+		reminder.TaskID, reminder.Title, reminder.UserID, reminder.UserEmail,
+		reminder.DueAt.Format(time.RFC3339))
+	return nil
+}
+
+// SMTPNotifier mengirim reminder melalui email
+type SMTPNotifier struct {
+	addr      string
+	auth      smtp.Auth
+	fromEmail string
+	fromName  string
+}
+
+// NewSMTPNotifier membuat instance SMTPNotifier
+func NewSMTPNotifier(host string, port int, username, password, fromEmail, fromName string) *SMTPNotifier {
+	return &SMTPNotifier{
+		addr:      fmt.Sprintf("%s:%d", host, port),
+		auth:      smtp.PlainAuth("", username, strings.TrimSpace(password), host),
+		fromEmail: fromEmail,
+		fromName:  fromName,
+	}
+}
+
+// headerBreaks dibuang dari nilai header agar title task tidak bisa
+// menyisipkan header lain (misalnya Bcc)
+var headerBreaks = strings.NewReplacer("\r", " ", "\n", " ")
+
+// Notify mengirim email reminder ke pemilik task
+func (n *SMTPNotifier) Notify(reminder Reminder) error {
+	if err := smtp.SendMail(n.addr, n.auth, n.fromEmail, []string{reminder.UserEmail}, n.Message(reminder)); err != nil {
+		return fmt.Errorf("failed to send reminder email: %w", err)
+	}
+
+	return nil
+}
+
+// Message menyusun email reminder beserta header-nya. Subject di-encode
+// sebagai MIME encoded-word setelah CR/LF pada title dibuang.
+func (n *SMTPNotifier) Message(reminder Reminder) []byte {
+	title := headerBreaks.Replace(reminder.Title)
+	subject := mime.QEncoding.Encode("utf-8", fmt.Sprintf("Reminder: %s", title))
+	body := fmt.Sprintf("Hi %s,\r\n\r\nYour task \"%s\" is due at %s.\r\n",
+		reminder.UserName, title, reminder.DueAt.Format(time.RFC1123))
+
+	msg := strings.Join([]string{
+		fmt.Sprintf("From: %s <%s>", n.fromName, n.fromEmail),
+		fmt.Sprintf("To: %s", reminder.UserEmail),
+		fmt.Sprintf("Subject: %s", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	return []byte(msg)
+}
@@ -0,0 +1,76 @@
+package patch
+
+import (
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Media type patch yang didukung
+const (
+	MergePatchType = "application/merge-patch+json" // RFC 7396
+	JSONPatchType  = "application/json-patch+json"  // RFC 6902
+)
+
+var (
+	// ErrUnsupportedType dikembalikan untuk media type selain MergePatchType dan JSONPatchType
+	ErrUnsupportedType = errors.New("patch: unsupported media type")
+	// ErrMalformed dikembalikan jika dokumen patch bukan JSON yang valid untuk jenisnya
+	ErrMalformed = errors.New("patch: malformed patch document")
+	// ErrTestFailed dikembalikan jika operasi "test" pada JSON Patch gagal
+	ErrTestFailed = errors.New("patch: test operation failed")
+	// ErrCannotApply dikembalikan jika patch valid tetapi tidak bisa diterapkan ke dokumen,
+	// misalnya path yang tidak ada
+	ErrCannotApply = errors.New("patch: patch cannot be applied")
+)
+
+// Apply menerapkan patch dengan media type mediaType ke dokumen JSON doc
+// dan mengembalikan dokumen hasilnya
+func Apply(mediaType string, doc, patch []byte) ([]byte, error) {
+	switch mediaType {
+	case MergePatchType:
+		// Merge patch harus berupa object, patch lain (array atau nilai
+		// tunggal) akan mengganti seluruh dokumen
+		if len(patch) == 0 || !isObject(patch) {
+			return nil, ErrMalformed
+		}
+		result, err := jsonpatch.MergePatch(doc, patch)
+		if err != nil {
+			return nil, ErrMalformed
+		}
+		return result, nil
+
+	case JSONPatchType:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, ErrMalformed
+		}
+		result, err := operations.Apply(doc)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, ErrTestFailed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCannotApply, err)
+		}
+		return result, nil
+
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+// isObject mengecek apakah dokumen JSON diawali object
+func isObject(data []byte) bool {
+	for _, c := range data {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
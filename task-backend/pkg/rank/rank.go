@@ -0,0 +1,230 @@
+// Package rank membuat key urutan berbasis string (fractional indexing).
+// Key dibandingkan secara byte per byte, sehingga kolom database harus
+// memakai collation biner. Di antara dua key selalu ada key baru tanpa
+// perlu mengubah key lain.
+//
+// Key terdiri dari bagian integer dan bagian pecahan. Karakter pertama
+// menentukan panjang bagian integer: 'a'-'z' untuk integer positif dengan
+// panjang 2-27, 'A'-'Z' untuk integer negatif dengan panjang 27-2. Dengan
+// begitu menambah key di akhir atau di awal list hanya menambah panjang
+// key secara logaritmik.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits adalah digit base-62 yang terurut sesuai nilai ASCII
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ErrInvalidKey dikembalikan jika key tidak valid atau batas tidak terurut
+var ErrInvalidKey = errors.New("rank: invalid key")
+
+// ErrExhausted dikembalikan jika tidak ada lagi key sebelum atau sesudah batas
+var ErrExhausted = errors.New("rank: key space exhausted")
+
+// smallestInteger adalah integer terkecil, key tidak boleh sama dengannya
+// agar masih ada key sebelumnya
+var smallestInteger = "A" + strings.Repeat(digits[:1], 26)
+
+// Between mengembalikan key yang urutannya di antara lower dan upper. String
+// kosong berarti tanpa batas, sehingga Between("", "") adalah key pertama.
+func Between(lower, upper string) (string, error) {
+	if lower != "" {
+		if err := validateKey(lower); err != nil {
+			return "", err
+		}
+	}
+	if upper != "" {
+		if err := validateKey(upper); err != nil {
+			return "", err
+		}
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return "", ErrInvalidKey
+	}
+
+	if lower == "" {
+		if upper == "" {
+			return "a" + digits[:1], nil
+		}
+		intUpper, fracUpper := splitKey(upper)
+		if intUpper == smallestInteger {
+			return intUpper + midpoint("", fracUpper), nil
+		}
+		if intUpper < upper {
+			return intUpper, nil
+		}
+		key, ok := decrementInteger(intUpper)
+		if !ok {
+			return "", ErrExhausted
+		}
+		return key, nil
+	}
+
+	intLower, fracLower := splitKey(lower)
+	if upper == "" {
+		if key, ok := incrementInteger(intLower); ok {
+			return key, nil
+		}
+		return intLower + midpoint(fracLower, ""), nil
+	}
+
+	intUpper, fracUpper := splitKey(upper)
+	if intLower == intUpper {
+		return intLower + midpoint(fracLower, fracUpper), nil
+	}
+	key, ok := incrementInteger(intLower)
+	if !ok {
+		return "", ErrExhausted
+	}
+	if key < upper {
+		return key, nil
+	}
+	return intLower + midpoint(fracLower, ""), nil
+}
+
+// midpoint mengembalikan pecahan di antara a dan b. b kosong berarti 1.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Prefix yang sama dipertahankan, a dianggap diisi '0' di kanan
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(digits, a[0])
+	}
+	digitB := len(digits)
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return digits[(digitA+digitB+1)/2 : (digitA+digitB+1)/2+1]
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return digits[digitA:digitA+1] + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return digits[0]
+}
+
+// integerLength mengembalikan panjang bagian integer dari karakter pertama key
+func integerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+func splitKey(key string) (string, string) {
+	n, _ := integerLength(key[0])
+	return key[:n], key[n:]
+}
+
+// validateKey memastikan key terbentuk dari digit yang valid, panjang
+// integer sesuai head, dan pecahan tidak diakhiri '0'
+func validateKey(key string) error {
+	n, ok := integerLength(key[0])
+	if !ok || len(key) < n || key == smallestInteger {
+		return ErrInvalidKey
+	}
+	for i := 1; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return ErrInvalidKey
+		}
+	}
+	if len(key) > n && key[len(key)-1] == digits[0] {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// incrementInteger menambah bagian integer dengan satu, false jika sudah maksimum
+func incrementInteger(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	carry := true
+	for i := len(digs) - 1; carry && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) + 1
+		if d == len(digits) {
+			digs[i] = digits[0]
+		} else {
+			digs[i] = digits[d]
+			carry = false
+		}
+	}
+	if !carry {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'Z':
+		return "a" + digits[:1], true
+	case 'z':
+		return "", false
+	}
+	head++
+	if head > 'a' {
+		digs = append(digs, digits[0])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+// decrementInteger mengurangi bagian integer dengan satu, false jika sudah minimum
+func decrementInteger(x string) (string, bool) {
+	head, digs := x[0], []byte(x[1:])
+	borrow := true
+	for i := len(digs) - 1; borrow && i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) - 1
+		if d < 0 {
+			digs[i] = digits[len(digits)-1]
+		} else {
+			digs[i] = digits[d]
+			borrow = false
+		}
+	}
+	if !borrow {
+		return string(head) + string(digs), true
+	}
+
+	switch head {
+	case 'a':
+		return "Z" + digits[len(digits)-1:], true
+	case 'A':
+		return "", false
+	}
+	head--
+	if head < 'Z' {
+		digs = append(digs, digits[len(digits)-1])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
@@ -0,0 +1,102 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// MaxOccurrences membatasi jumlah occurrence yang dihitung dalam satu panggilan
+const MaxOccurrences = 50
+
+// ErrInvalidRule dikembalikan saat RRULE tidak dapat di-parse
+var ErrInvalidRule = errors.New("recurrence: invalid RRULE")
+
+// Normalize mem-parse RRULE (RFC 5545, dengan atau tanpa prefix "RRULE:")
+// dan mengembalikan bentuk kanoniknya. DTSTART tidak diterima karena
+// jadwal selalu dihitung dari due date task.
+func Normalize(rule string) (string, error) {
+	option, err := parse(rule)
+	if err != nil {
+		return "", err
+	}
+
+	return option.RRuleString(), nil
+}
+
+// Next menghitung sampai count occurrence setelah after untuk rule yang
+// dimulai pada dtstart. Hasil bisa lebih sedikit jika rule sudah berakhir.
+func Next(rule string, dtstart, after time.Time, count int) ([]time.Time, error) {
+	option, err := parse(rule)
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = dtstart.UTC().Truncate(time.Second)
+
+	r, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	if count > MaxOccurrences {
+		count = MaxOccurrences
+	}
+
+	var occurrences []time.Time
+	for len(occurrences) < count {
+		next := r.After(after, false)
+		if next.IsZero() {
+			break
+		}
+		occurrences = append(occurrences, next)
+		after = next
+	}
+
+	return occurrences, nil
+}
+
+// EndBefore mengubah rule yang dimulai pada dtstart agar berakhir sebelum t,
+// dipakai saat series dipecah untuk "semua occurrence berikutnya". COUNT
+// diganti dengan UNTIL karena RFC 5545 tidak mengizinkan keduanya sekaligus.
+func EndBefore(rule string, dtstart, t time.Time) (string, error) {
+	option, err := parse(rule)
+	if err != nil {
+		return "", err
+	}
+
+	until := t.UTC().Add(-time.Second)
+	if option.Count != 0 {
+		option.Dtstart = dtstart.UTC().Truncate(time.Second)
+		r, err := rrule.NewRRule(*option)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+		if all := r.All(); len(all) > 0 && all[len(all)-1].Before(until) {
+			until = all[len(all)-1]
+		}
+		option.Count = 0
+		option.Dtstart = time.Time{}
+	}
+	if option.Until.IsZero() || option.Until.After(until) {
+		option.Until = until
+	}
+
+	return option.RRuleString(), nil
+}
+
+func parse(rule string) (*rrule.ROption, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" || strings.ContainsAny(rule, "\r\n") {
+		return nil, ErrInvalidRule
+	}
+
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	return option, nil
+}
@@ -0,0 +1,207 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MinTermLength mengikuti innodb_ft_min_token_size default. Kata yang lebih
+// pendek tidak diindeks FULLTEXT sehingga tidak dipakai di MATCH ... AGAINST.
+const MinTermLength = 3
+
+// Query adalah hasil parsing input pencarian user
+type Query struct {
+	Terms    []string // kata biasa, dicocokkan sebagai prefix
+	Phrases  []string // frasa dalam tanda kutip, dicocokkan persis
+	Excluded []string // kata atau frasa dengan awalan "-"
+}
+
+// Parse membaca input pencarian: kata biasa wajib ada, "frasa dalam kutip"
+// dicocokkan utuh, dan awalan - mengecualikan kata atau frasa. Karakter
+// operator boolean lain diabaikan.
+func Parse(input string) Query {
+	var q Query
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		exclude := false
+		if runes[i] == '-' {
+			exclude = true
+			i++
+		}
+
+		var words []string
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			words = []string{strings.Join(splitWords(string(runes[i+1:end])), " ")}
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) {
+				end++
+			}
+			words = splitWords(string(runes[i:end]))
+			i = end
+		}
+
+		for _, word := range words {
+			switch {
+			case word == "":
+			case exclude:
+				q.Excluded = append(q.Excluded, word)
+			case strings.Contains(word, " "):
+				q.Phrases = append(q.Phrases, word)
+			case len([]rune(word)) >= MinTermLength:
+				q.Terms = append(q.Terms, word)
+			}
+		}
+	}
+
+	return q
+}
+
+// IsEmpty true jika query tidak memiliki kata atau frasa yang bisa dicari
+// dengan FULLTEXT. MySQL tidak mengembalikan hasil untuk query yang hanya
+// berisi pengecualian.
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0
+}
+
+// BooleanMode menyusun string untuk MATCH ... AGAINST (? IN BOOLEAN MODE)
+func (q Query) BooleanMode() string {
+	var parts []string
+	for _, term := range q.Terms {
+		parts = append(parts, "+"+term+"*")
+	}
+	for _, phrase := range q.Phrases {
+		parts = append(parts, `+"`+phrase+`"`)
+	}
+	for _, excluded := range q.Excluded {
+		if strings.Contains(excluded, " ") {
+			excluded = `"` + excluded + `"`
+		}
+		parts = append(parts, "-"+excluded)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Highlight mengembalikan potongan text di sekitar kecocokan pertama dengan
+// panjang maksimum maxLen karakter. Text di-escape sebagai HTML dan setiap
+// kecocokan dibungkus <mark>. String kosong dikembalikan jika tidak ada
+// kecocokan.
+func Highlight(text string, q Query, maxLen int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	matches := q.find(runes, lower)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	// Mulai snippet sedikit sebelum kecocokan pertama, pada batas kata
+	start, end := 0, len(runes)
+	if len(runes) > maxLen {
+		start = matches[0][0] - maxLen/4
+		if start < 0 {
+			start = 0
+		}
+		for start > 0 && isWordRune(runes[start-1]) {
+			start--
+		}
+		end = start + maxLen
+		if end > len(runes) {
+			end = len(runes)
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		s, e := m[0], m[1]
+		if e <= pos || s >= end {
+			continue
+		}
+		if s < pos {
+			s = pos
+		}
+		if e > end {
+			e = end
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[s:e])))
+		b.WriteString("</mark>")
+		pos = e
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
+
+// find mencari posisi [start, end) setiap kecocokan term dan frasa yang
+// diawali batas kata, urut dan tidak saling tumpang tindih
+func (q Query) find(runes, lower []rune) [][2]int {
+	var matches [][2]int
+	needles := append(append([]string{}, q.Terms...), q.Phrases...)
+
+	for _, needle := range needles {
+		n := []rune(needle)
+		for i := 0; i+len(n) <= len(lower); i++ {
+			if i > 0 && isWordRune(lower[i-1]) || string(lower[i:i+len(n)]) != needle {
+				continue
+			}
+			// Term cocok sebagai prefix, tandai sampai akhir kata
+			end := i + len(n)
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+			matches = append(matches, [2]int{i, end})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+
+	var merged [][2]int
+	for _, m := range matches {
+		if last := len(merged) - 1; last >= 0 && m[0] <= merged[last][1] {
+			if m[1] > merged[last][1] {
+				merged[last][1] = m[1]
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+
+	return merged
+}
+
+// splitWords memecah text menjadi kata huruf kecil berisi huruf dan angka,
+// sama seperti tokenizer FULLTEXT memecah tanda baca
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -1,14 +1,47 @@
 package validator
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/Mahathirrr/task-management-backend/internal/model"
 )
 
 var validate *validator.Validate
 
+// statusKeyPattern membatasi key status workflow ke huruf kecil, angka, dan underscore
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
 func init() {
 	validate = validator.New()
+	validate.RegisterValidation("task_priority", validateTaskPriority)
+	validate.RegisterValidation("label_name", validateLabelName)
+	validate.RegisterValidation("task_status", validateTaskStatus)
+	validate.RegisterValidation("status_key", validateStatusKey)
+}
+
+// validateTaskPriority memastikan field berisi priority task yang didukung
+func validateTaskPriority(fl validator.FieldLevel) bool {
+	return model.TaskPriority(fl.Field().String()).IsValid()
+}
+
+// validateTaskStatus memastikan field berisi status yang dikenal oleh salah satu workflow.
+// Kecocokan dengan workflow task itu sendiri dicek oleh TaskService.
+func validateTaskStatus(fl validator.FieldLevel) bool {
+	return model.TaskStatus(fl.Field().String()).IsKnown()
+}
+
+// validateStatusKey memastikan key status workflow aman dipakai sebagai nilai query
+func validateStatusKey(fl validator.FieldLevel) bool {
+	return statusKeyPattern.MatchString(fl.Field().String())
+}
+
+// validateLabelName memastikan nama label tidak kosong dan tidak mengandung koma,
+// karena koma dipakai sebagai pemisah pada filter ?labels=
+func validateLabelName(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	return strings.TrimSpace(name) != "" && !strings.Contains(name, ",")
 }
 
 // ValidateStruct memvalidasi struct dan mengembalikan error details
@@ -33,6 +66,8 @@ func getErrorMessage(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
 		return fe.Field() + " is required"
+	case "required_if":
+		return fe.Field() + " is required for this operation"
 	case "email":
 		return "Invalid email format"
 	case "min":
@@ -41,6 +76,18 @@ func getErrorMessage(fe validator.FieldError) string {
 		return fe.Field() + " must be at most " + fe.Param() + " characters"
 	case "oneof":
 		return fe.Field() + " must be one of: " + fe.Param()
+	case "task_priority":
+		return fe.Field() + " must be one of: low medium high urgent"
+	case "task_status":
+		return fe.Field() + " must be a status defined by a workflow"
+	case "status_key":
+		return fe.Field() + " must contain only lowercase letters, digits and underscores"
+	case "label_name":
+		return fe.Field() + " must not be blank or contain commas"
+	case "hexcolor":
+		return fe.Field() + " must be a hex color such as #ff8800"
+	case "gt":
+		return fe.Field() + " must be greater than " + fe.Param()
 	default:
 		return fe.Field() + " is invalid"
 	}
@@ -0,0 +1,195 @@
+package unit
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/storage"
+)
+
+// Mock AttachmentRepository for testing
+type mockAttachmentRepository struct {
+	attachments map[int]*model.Attachment
+	nextID      int
+}
+
+func newMockAttachmentRepository() *mockAttachmentRepository {
+	return &mockAttachmentRepository{
+		attachments: make(map[int]*model.Attachment),
+		nextID:      1,
+	}
+}
+
+func (m *mockAttachmentRepository) Create(attachment *model.Attachment) error {
+	attachment.ID = m.nextID
+	m.nextID++
+	m.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (m *mockAttachmentRepository) GetByID(id int) (*model.Attachment, error) {
+	attachment, exists := m.attachments[id]
+	if !exists {
+		return nil, nil
+	}
+	return attachment, nil
+}
+
+func (m *mockAttachmentRepository) GetByTaskID(taskID int) ([]model.Attachment, error) {
+	return m.GetByTaskIDs([]int{taskID})
+}
+
+func (m *mockAttachmentRepository) GetByTaskIDs(taskIDs []int) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	for _, attachment := range m.attachments {
+		for _, taskID := range taskIDs {
+			if attachment.TaskID == taskID {
+				attachments = append(attachments, *attachment)
+			}
+		}
+	}
+	return attachments, nil
+}
+
+func (m *mockAttachmentRepository) Delete(id int) error {
+	delete(m.attachments, id)
+	return nil
+}
+
+// memoryStorage menyimpan file di memory untuk testing
+type memoryStorage struct {
+	files map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: make(map[string][]byte)}
+}
+
+func (s *memoryStorage) Save(key string, content io.Reader) (int64, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+	s.files[key] = data
+	return int64(len(data)), nil
+}
+
+func (s *memoryStorage) Open(key string) (io.ReadCloser, error) {
+	data, exists := s.files[key]
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func TestAttachments(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n")
+
+	newAttachmentService := func(t *testing.T) (*taskFixture, service.TaskService, service.AttachmentService, *model.Task) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		assigneeID := 2
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		attachmentService := service.NewAttachmentService(f.attachmentRepo, taskService, f.fileStorage, 64, []string{"image/png", "text/plain"})
+		return f, taskService, attachmentService, task
+	}
+
+	t.Run("UploadDetectsContentType", func(t *testing.T) {
+		f, _, attachmentService, task := newAttachmentService(t)
+
+		attachment, err := attachmentService.UploadAttachment(task.ID, 2, "../../screen.png", bytes.NewReader(pngHeader), false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if attachment.ContentType != "image/png" {
+			t.Errorf("Expected content type image/png, got %s", attachment.ContentType)
+		}
+		if attachment.FileName != "screen.png" {
+			t.Errorf("Expected path to be stripped from file name, got %q", attachment.FileName)
+		}
+		if attachment.Size != int64(len(pngHeader)) || len(f.fileStorage.files) != 1 {
+			t.Errorf("Expected file of %d bytes to be stored, got size %d", len(pngHeader), attachment.Size)
+		}
+	})
+
+	t.Run("RejectsDisallowedType", func(t *testing.T) {
+		f, _, attachmentService, task := newAttachmentService(t)
+
+		_, err := attachmentService.UploadAttachment(task.ID, 1, "page.html", strings.NewReader("<html><body>hi</body></html>"), false)
+		if err == nil || err.Error() != model.ErrAttachmentTypeNotAllowed {
+			t.Errorf("Expected %q error, got %v", model.ErrAttachmentTypeNotAllowed, err)
+		}
+		if len(f.fileStorage.files) != 0 {
+			t.Errorf("Expected no stored files, got %d", len(f.fileStorage.files))
+		}
+	})
+
+	t.Run("RejectsOversizedFile", func(t *testing.T) {
+		f, _, attachmentService, task := newAttachmentService(t)
+
+		_, err := attachmentService.UploadAttachment(task.ID, 1, "app.log", strings.NewReader(strings.Repeat("x", 65)), false)
+		if err == nil || err.Error() != model.ErrAttachmentTooLarge {
+			t.Errorf("Expected %q error, got %v", model.ErrAttachmentTooLarge, err)
+		}
+		if len(f.fileStorage.files) != 0 {
+			t.Errorf("Expected partial file to be removed, got %d files", len(f.fileStorage.files))
+		}
+	})
+
+	t.Run("FollowsTaskAuthorization", func(t *testing.T) {
+		_, _, attachmentService, task := newAttachmentService(t)
+
+		_, err := attachmentService.UploadAttachment(task.ID, 3, "app.log", strings.NewReader("log"), false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("PurgeRemovesFiles", func(t *testing.T) {
+		f, taskService, attachmentService, task := newAttachmentService(t)
+
+		subtask, err := taskService.CreateSubtask(task.ID, 1, &model.TaskCreateRequest{Title: "Subtask"}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, taskID := range []int{task.ID, subtask.ID} {
+			if _, err := attachmentService.UploadAttachment(taskID, 1, "app.log", strings.NewReader("log"), false); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		if err := taskService.DeleteTask(task.ID, 1, false, model.SubtaskDeleteCascade); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// File tetap ada selama task masih di trash
+		if len(f.fileStorage.files) != 2 {
+			t.Fatalf("Expected files to be kept in trash, got %d", len(f.fileStorage.files))
+		}
+
+		purged, err := taskService.PurgeTrash(time.Now().Add(time.Second))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if purged != 2 {
+			t.Errorf("Expected 2 purged tasks, got %d", purged)
+		}
+		if len(f.fileStorage.files) != 0 {
+			t.Errorf("Expected all files to be deleted, got %d", len(f.fileStorage.files))
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+)
+
+// Mock CalendarTokenRepository for testing
+type mockCalendarTokenRepository struct {
+	tokens map[int]*model.CalendarToken
+}
+
+func (m *mockCalendarTokenRepository) Save(token *model.CalendarToken) error {
+	copied := *token
+	copied.CreatedAt = time.Now()
+	m.tokens[token.UserID] = &copied
+	return nil
+}
+
+func (m *mockCalendarTokenRepository) GetByUserID(userID int) (*model.CalendarToken, error) {
+	return m.tokens[userID], nil
+}
+
+func (m *mockCalendarTokenRepository) GetByHash(tokenHash string) (*model.CalendarToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCalendarTokenRepository) Delete(userID int) error {
+	delete(m.tokens, userID)
+	return nil
+}
+
+func TestCalendarFeed(t *testing.T) {
+	newCalendarService := func() (service.CalendarService, *mockCalendarTokenRepository, service.TaskService) {
+		f := newTaskFixture()
+		taskService := f.service()
+		tokenRepo := &mockCalendarTokenRepository{tokens: map[int]*model.CalendarToken{}}
+		return service.NewCalendarService(tokenRepo, f.userRepo, taskService), tokenRepo, taskService
+	}
+
+	t.Run("TokenLifecycle", func(t *testing.T) {
+		calendarService, tokenRepo, _ := newCalendarService()
+
+		if _, err := calendarService.GetToken(1); err == nil || err.Error() != model.ErrCalendarTokenNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrCalendarTokenNotFound, err)
+		}
+
+		first, _, err := calendarService.RegenerateToken(1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if tokenRepo.tokens[1].TokenHash == first {
+			t.Error("Expected token to be stored hashed")
+		}
+		user, err := calendarService.GetFeedUser(first)
+		if err != nil || user.ID != 1 {
+			t.Fatalf("Expected token to resolve to user 1, got %v, %v", user, err)
+		}
+
+		second, _, err := calendarService.RegenerateToken(1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if second == first {
+			t.Error("Expected a new token")
+		}
+		if _, err := calendarService.GetFeedUser(first); err == nil || err.Error() != model.ErrCalendarTokenNotFound {
+			t.Errorf("Expected old token to stop working, got %v", err)
+		}
+
+		if err := calendarService.RevokeToken(1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := calendarService.GetFeedUser(second); err == nil || err.Error() != model.ErrCalendarTokenNotFound {
+			t.Errorf("Expected revoked token to stop working, got %v", err)
+		}
+		if err := calendarService.RevokeToken(1); err == nil || err.Error() != model.ErrCalendarTokenNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrCalendarTokenNotFound, err)
+		}
+	})
+
+	t.Run("OnlyTasksWithRecentDueDates", func(t *testing.T) {
+		calendarService, _, taskService := newCalendarService()
+
+		soon := time.Now().Add(48 * time.Hour)
+		old := time.Now().Add(-model.CalendarFeedLookback - time.Hour)
+		requests := []model.TaskCreateRequest{
+			{Title: "Due soon", DueAt: &soon},
+			{Title: "Due long ago", DueAt: &old},
+			{Title: "No due date"},
+		}
+		for i := range requests {
+			if _, err := taskService.CreateTask(1, &requests[i]); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		if _, err := taskService.CreateTask(2, &model.TaskCreateRequest{Title: "Other user", DueAt: &soon}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var titles []string
+		err := calendarService.GetFeedTasks(1, func(task *model.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(titles) != 1 || titles[0] != "Due soon" {
+			t.Errorf("Expected only %q, got %v", "Due soon", titles)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+)
+
+// Mock CommentRepository for testing
+type mockCommentRepository struct {
+	comments map[int]*model.Comment
+	nextID   int
+}
+
+func newMockCommentRepository() *mockCommentRepository {
+	return &mockCommentRepository{
+		comments: make(map[int]*model.Comment),
+		nextID:   1,
+	}
+}
+
+func (m *mockCommentRepository) Create(comment *model.Comment) error {
+	comment.ID = m.nextID
+	m.nextID++
+	m.comments[comment.ID] = comment
+	return nil
+}
+
+func (m *mockCommentRepository) GetByID(id int) (*model.Comment, error) {
+	comment, exists := m.comments[id]
+	if !exists {
+		return nil, nil
+	}
+	copied := *comment
+	return &copied, nil
+}
+
+func (m *mockCommentRepository) GetByTaskID(taskID int, page, limit int) ([]model.Comment, int, error) {
+	var comments []model.Comment
+	for id := 1; id < m.nextID; id++ {
+		comment, exists := m.comments[id]
+		if !exists || comment.TaskID != taskID || comment.ParentID != nil {
+			continue
+		}
+		root := *comment
+		for replyID := 1; replyID < m.nextID; replyID++ {
+			reply, exists := m.comments[replyID]
+			if exists && reply.ParentID != nil && *reply.ParentID == root.ID {
+				root.Replies = append(root.Replies, *reply)
+			}
+		}
+		comments = append(comments, root)
+	}
+	return comments, len(comments), nil
+}
+
+func (m *mockCommentRepository) Update(comment *model.Comment) error {
+	m.comments[comment.ID] = comment
+	return nil
+}
+
+func (m *mockCommentRepository) Delete(id int) error {
+	delete(m.comments, id)
+	// Meniru foreign key ON DELETE CASCADE pada parent_id
+	for replyID, comment := range m.comments {
+		if comment.ParentID != nil && *comment.ParentID == id {
+			delete(m.comments, replyID)
+		}
+	}
+	return nil
+}
+
+func TestComments(t *testing.T) {
+	// Task milik user 1 yang di-assign ke user 2; user 3 tidak punya akses
+	newCommentService := func(t *testing.T) (service.CommentService, *model.Task) {
+		taskService := newTaskFixture().service()
+
+		assigneeID := 2
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return service.NewCommentService(newMockCommentRepository(), taskService), task
+	}
+
+	t.Run("FollowsTaskAuthorization", func(t *testing.T) {
+		commentService, task := newCommentService(t)
+
+		if _, err := commentService.CreateComment(task.ID, 2, &model.CommentCreateRequest{Body: "On it"}, false); err != nil {
+			t.Errorf("Expected assignee to comment, got %v", err)
+		}
+
+		_, err := commentService.CreateComment(task.ID, 3, &model.CommentCreateRequest{Body: "Hi"}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+
+		_, err = commentService.GetTaskComments(task.ID, 3, 1, 10, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("RepliesFormSingleLevelThread", func(t *testing.T) {
+		commentService, task := newCommentService(t)
+
+		root, err := commentService.CreateComment(task.ID, 1, &model.CommentCreateRequest{Body: "Root"}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		reply, err := commentService.CreateComment(task.ID, 2, &model.CommentCreateRequest{Body: "Reply", ParentID: &root.ID}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		nested, err := commentService.CreateComment(task.ID, 1, &model.CommentCreateRequest{Body: "Nested", ParentID: &reply.ID}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if nested.ParentID == nil || *nested.ParentID != root.ID {
+			t.Errorf("Expected reply to a reply to attach to root %d, got %v", root.ID, nested.ParentID)
+		}
+
+		resp, err := commentService.GetTaskComments(task.ID, 1, 1, 10, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Total != 1 || len(resp.Comments) != 1 {
+			t.Fatalf("Expected 1 root comment, got total %d", resp.Total)
+		}
+		if len(resp.Comments[0].Replies) != 2 {
+			t.Errorf("Expected 2 replies, got %d", len(resp.Comments[0].Replies))
+		}
+	})
+
+	t.Run("ParentMustBelongToTask", func(t *testing.T) {
+		commentService, task := newCommentService(t)
+
+		missing := 99
+		_, err := commentService.CreateComment(task.ID, 1, &model.CommentCreateRequest{Body: "Reply", ParentID: &missing}, false)
+		if err == nil || err.Error() != model.ErrInvalidParentComment {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidParentComment, err)
+		}
+	})
+
+	t.Run("OnlyAuthorCanEdit", func(t *testing.T) {
+		commentService, task := newCommentService(t)
+
+		comment, err := commentService.CreateComment(task.ID, 2, &model.CommentCreateRequest{Body: "Draft"}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = commentService.UpdateComment(task.ID, comment.ID, 1, &model.CommentUpdateRequest{Body: "Changed"}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+
+		updated, err := commentService.UpdateComment(task.ID, comment.ID, 2, &model.CommentUpdateRequest{Body: "Final"}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.Body != "Final" {
+			t.Errorf("Expected body %q, got %q", "Final", updated.Body)
+		}
+	})
+
+	t.Run("DeleteRemovesReplies", func(t *testing.T) {
+		commentService, task := newCommentService(t)
+
+		root, _ := commentService.CreateComment(task.ID, 1, &model.CommentCreateRequest{Body: "Root"}, false)
+		_, _ = commentService.CreateComment(task.ID, 2, &model.CommentCreateRequest{Body: "Reply", ParentID: &root.ID}, false)
+
+		if err := commentService.DeleteComment(task.ID, root.ID, 2, false); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+		if err := commentService.DeleteComment(task.ID, root.ID, 1, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		resp, _ := commentService.GetTaskComments(task.ID, 1, 1, 10, false)
+		if resp.Total != 0 {
+			t.Errorf("Expected no comments, got %d", resp.Total)
+		}
+	})
+}
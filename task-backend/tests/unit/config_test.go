@@ -0,0 +1,48 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() *config.Config {
+		return &config.Config{
+			Reminder: config.ReminderConfig{Enabled: true, LeadTime: 30 * time.Minute, Interval: time.Minute},
+			Trash:    config.TrashConfig{RetentionDays: 30, PurgeInterval: time.Hour},
+		}
+	}
+
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *config.Config)
+	}{
+		{"ZeroReminderInterval", func(c *config.Config) { c.Reminder.Interval = 0 }},
+		{"NegativeLeadTime", func(c *config.Config) { c.Reminder.LeadTime = -time.Minute }},
+		{"ZeroPurgeInterval", func(c *config.Config) { c.Trash.PurgeInterval = 0 }},
+		{"NegativeRetention", func(c *config.Config) { c.Trash.RetentionDays = -1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			if err := c.Validate(); err == nil {
+				t.Error("Expected error for invalid config")
+			}
+		})
+	}
+
+	// Interval scheduler yang dinonaktifkan tidak dipakai sehingga tidak dicek
+	c := valid()
+	c.Reminder = config.ReminderConfig{Enabled: false}
+	c.Trash = config.TrashConfig{RetentionDays: 0}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Expected disabled schedulers to skip validation, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package unit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/filterql"
+)
+
+var testFilterColumns = filterql.Columns{
+	"title":       "t.title",
+	"status":      "t.status",
+	"priority":    "t.priority",
+	"assignee_id": "t.assignee_id",
+	"created_at":  "t.created_at",
+	"due_at":      "t.due_at",
+	"updated_at":  "t.updated_at",
+}
+
+func TestFilterQLCompile(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		clause string
+		args   []interface{}
+	}{
+		{
+			name:   "InAndDate",
+			input:  "status in (pending, in_progress) and created_at > 2026-01-01",
+			clause: "((t.status IN (?, ?)) AND (t.created_at > ?))",
+			args:   []interface{}{"pending", "in_progress", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:   "PrecedenceAndGrouping",
+			input:  "priority = HIGH or not (due_at is null and title ~ '50%')",
+			clause: "((t.priority = ?) OR NOT ((t.due_at IS NULL) AND (t.title LIKE ?)))",
+			args:   []interface{}{"high", `%50\%%`},
+		},
+		{
+			name:   "NullableNotEqualIncludesNull",
+			input:  "assignee_id != 3 and assignee_id not in (4)",
+			clause: "(NOT (t.assignee_id <=> ?) AND (t.assignee_id IS NULL OR t.assignee_id NOT IN (?)))",
+			args:   []interface{}{3, 4},
+		},
+		{
+			name:   "QuotedValueIsNotSQL",
+			input:  `title = "x' OR 1=1 --"`,
+			clause: "(t.title = ?)",
+			args:   []interface{}{"x' OR 1=1 --"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := filterql.Parse(tt.input, model.TaskFilterFields)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			clause, args, err := filterql.SQL(node, testFilterColumns)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if clause != tt.clause {
+				t.Errorf("Expected clause %s, got %s", tt.clause, clause)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Expected args %v, got %v", tt.args, args)
+			}
+		})
+	}
+}
+
+func TestFilterQLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pos   int
+		token string
+	}{
+		{"UnknownField", "status = pending and password = x", 21, "password"},
+		{"UnsupportedOperator", "created_at ~ 2026", 11, "~"},
+		{"InvalidDate", "due_at < tomorrow", 9, "tomorrow"},
+		{"InvalidEnum", "priority in (low, critical)", 18, "critical"},
+		{"NotNullable", "status is null", 0, "status"},
+		{"MissingParen", "(status = pending", 17, ""},
+		{"TrailingToken", "status = pending pending", 17, "pending"},
+		{"UnterminatedString", "title = 'abc", 8, "'abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := filterql.Parse(tt.input, model.TaskFilterFields)
+			var qlErr *filterql.Error
+			if !errors.As(err, &qlErr) {
+				t.Fatalf("Expected filterql error, got %v", err)
+			}
+			if qlErr.Pos != tt.pos || qlErr.Token != tt.token {
+				t.Errorf("Expected error at %d near %q, got %d near %q (%v)", tt.pos, tt.token, qlErr.Pos, qlErr.Token, err)
+			}
+		})
+	}
+}
+
+func TestFilterQLSort(t *testing.T) {
+	sorts, err := filterql.ParseSort("-updated_at, title", model.TaskSortFields)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	clause, err := filterql.OrderBy(sorts, testFilterColumns)
+	if err != nil || clause != "t.updated_at DESC, t.title ASC" {
+		t.Errorf("Expected ordered clause, got %q (%v)", clause, err)
+	}
+
+	var qlErr *filterql.Error
+	if _, err := filterql.ParseSort("title,password", model.TaskSortFields); !errors.As(err, &qlErr) || qlErr.Pos != 6 {
+		t.Errorf("Expected error at position 6, got %v", err)
+	}
+	if _, err := filterql.ParseSort("title,-title", model.TaskSortFields); err == nil {
+		t.Error("Expected error for duplicate sort field")
+	}
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/ical"
+)
+
+func TestICalWriter(t *testing.T) {
+	var b strings.Builder
+	w := ical.NewWriter(&b)
+
+	w.Begin("VEVENT")
+	w.Text("SUMMARY", "Deploy; notify team, then\nclose \\ done")
+	w.TextList("CATEGORIES", []string{"ops", "a,b"})
+	w.Time("DTSTART", time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("WIB", 7*3600)))
+	w.End("VEVENT")
+	if err := w.Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "BEGIN:VEVENT\r\n" +
+		"SUMMARY:Deploy\\; notify team\\, then\\nclose \\\\ done\r\n" +
+		"CATEGORIES:ops,a\\,b\r\n" +
+		"DTSTART:20260301T023000Z\r\n" +
+		"END:VEVENT\r\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestICalLineFolding(t *testing.T) {
+	var b strings.Builder
+	w := ical.NewWriter(&b)
+
+	value := strings.Repeat("é", 100)
+	w.Text("DESCRIPTION", value)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected folded lines, got %q", b.String())
+	}
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("Line %d is %d octets", i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Fatalf("Expected continuation line to start with a space, got %q", line)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != "DESCRIPTION:"+value {
+		t.Errorf("Expected unfolded value to match, got %q", unfolded.String())
+	}
+}
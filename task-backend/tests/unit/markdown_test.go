@@ -0,0 +1,120 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/markdown"
+)
+
+func TestMarkdownRender(t *testing.T) {
+	html := markdown.Render("# Plan\n\n- [ ] draft\n- [x] review\n\n**bold** [docs](https://example.com)")
+
+	expected := []string{
+		"<h1>Plan</h1>",
+		`<li><input type="checkbox" disabled=""> draft</li>`,
+		`<li><input checked="" type="checkbox" disabled=""> review</li>`,
+		"<strong>bold</strong>",
+		`<a href="https://example.com" rel="nofollow noopener noreferrer">docs</a>`,
+	}
+	for _, part := range expected {
+		if !strings.Contains(html, part) {
+			t.Errorf("Expected %q in %q", part, html)
+		}
+	}
+}
+
+func TestTaskDescriptionHTML(t *testing.T) {
+	description := "**bold**"
+	data, err := json.Marshal(model.Task{Title: "Task", Description: &description})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), `"description_html":"\u003cp\u003e\u003cstrong\u003ebold`) {
+		t.Errorf("Expected rendered description_html, got %s", data)
+	}
+
+	data, _ = json.Marshal(&model.Task{Title: "Task"})
+	if !strings.Contains(string(data), `"description_html":null`) {
+		t.Errorf("Expected null description_html, got %s", data)
+	}
+}
+
+func TestMarkdownSanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"RawScript", "<script>alert(1)</script>"},
+		{"RawImage", `<img src=x onerror="alert(1)">`},
+		{"JavascriptLink", "[click](javascript:alert(1))"},
+		{"DataImage", "![x](data:text/html;base64,PHNjcmlwdD4=)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := markdown.Render(tt.source)
+			for _, bad := range []string{"<script", "onerror", "javascript:", "data:"} {
+				if strings.Contains(strings.ToLower(html), bad) {
+					t.Errorf("Expected %q to be removed, got %q", bad, html)
+				}
+			}
+		})
+	}
+
+	html := markdown.Sanitize(`<p onclick="x" style="color:red">ok</p><a href="JaVaScRiPt:x">a</a><input type="text"><iframe src="x">frame</iframe>`)
+	expected := `<p>ok</p><a rel="nofollow noopener noreferrer">a</a>`
+	if html != expected {
+		t.Errorf("Expected %q, got %q", expected, html)
+	}
+}
+
+func TestMarkdownToggleTask(t *testing.T) {
+	source := "- [ ] one\n- [x] two\n  - [ ] nested\n\n```\n- [ ] in code\n```\n\n1. [ ] ordered\n"
+
+	t.Run("CheckNested", func(t *testing.T) {
+		updated, err := markdown.ToggleTask(source, 2, true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := strings.Replace(source, "  - [ ] nested", "  - [x] nested", 1)
+		if updated != expected {
+			t.Errorf("Expected %q, got %q", expected, updated)
+		}
+	})
+
+	t.Run("SkipCodeBlock", func(t *testing.T) {
+		updated, err := markdown.ToggleTask(source, 3, true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(updated, "1. [x] ordered") || !strings.Contains(updated, "- [ ] in code") {
+			t.Errorf("Expected ordered item to be checked, got %q", updated)
+		}
+	})
+
+	t.Run("Uncheck", func(t *testing.T) {
+		updated, err := markdown.ToggleTask(source, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.Contains(updated, "- [ ] two") {
+			t.Errorf("Expected item to be unchecked, got %q", updated)
+		}
+	})
+
+	t.Run("AlreadyChecked", func(t *testing.T) {
+		updated, err := markdown.ToggleTask(source, 1, true)
+		if err != nil || updated != source {
+			t.Errorf("Expected source unchanged, got %q, %v", updated, err)
+		}
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		if _, err := markdown.ToggleTask(source, 4, true); !errors.Is(err, markdown.ErrTaskItemNotFound) {
+			t.Errorf("Expected ErrTaskItemNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"bytes"
+	"mime"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/notifier"
+)
+
+func TestSMTPNotifierMessage(t *testing.T) {
+	n := notifier.NewSMTPNotifier("localhost", 25, "", "", "noreply@example.com", "Tasks")
+	reminder := notifier.Reminder{
+		TaskID:    1,
+		UserEmail: "user@example.com",
+		UserName:  "User",
+		Title:     "Pay invoice\r\nBcc: attacker@example.com",
+		DueAt:     time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(n.Message(reminder)))
+	if err != nil {
+		t.Fatalf("Expected valid message, got %v", err)
+	}
+	if bcc := msg.Header.Get("Bcc"); bcc != "" {
+		t.Errorf("Expected no injected Bcc header, got %q", bcc)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("Expected decodable subject, got %v", err)
+	}
+	expected := "Reminder: Pay invoice  Bcc: attacker@example.com"
+	if subject != expected {
+		t.Errorf("Expected subject %q, got %q", expected, subject)
+	}
+}
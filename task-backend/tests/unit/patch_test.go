@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/patch"
+)
+
+func TestPatchApply(t *testing.T) {
+	doc := []byte(`{"title":"Task","description":"Notes","label_ids":[1,2]}`)
+
+	decode := func(t *testing.T, data []byte) map[string]interface{} {
+		var result map[string]interface{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Expected valid JSON, got %v", err)
+		}
+		return result
+	}
+
+	t.Run("MergePatchNullRemovesField", func(t *testing.T) {
+		result, err := patch.Apply(patch.MergePatchType, doc, []byte(`{"title":"Renamed","description":null}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		fields := decode(t, result)
+		if fields["title"] != "Renamed" {
+			t.Errorf("Expected title to be replaced, got %v", fields["title"])
+		}
+		if _, exists := fields["description"]; exists {
+			t.Errorf("Expected description to be removed, got %v", fields["description"])
+		}
+	})
+
+	t.Run("MergePatchMustBeObject", func(t *testing.T) {
+		_, err := patch.Apply(patch.MergePatchType, doc, []byte(`[{"op":"remove","path":"/title"}]`))
+		if !errors.Is(err, patch.ErrMalformed) {
+			t.Errorf("Expected ErrMalformed, got %v", err)
+		}
+	})
+
+	t.Run("JSONPatchOperations", func(t *testing.T) {
+		operations := `[
+			{"op":"test","path":"/title","value":"Task"},
+			{"op":"replace","path":"/description","value":null},
+			{"op":"add","path":"/label_ids/-","value":3}
+		]`
+		result, err := patch.Apply(patch.JSONPatchType, doc, []byte(operations))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		fields := decode(t, result)
+		if fields["description"] != nil {
+			t.Errorf("Expected description to be null, got %v", fields["description"])
+		}
+		if labels := fields["label_ids"].([]interface{}); len(labels) != 3 {
+			t.Errorf("Expected 3 labels, got %v", labels)
+		}
+	})
+
+	t.Run("JSONPatchErrors", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			operations string
+			expected   error
+		}{
+			{"TestFailed", `[{"op":"test","path":"/title","value":"Other"}]`, patch.ErrTestFailed},
+			{"MissingPath", `[{"op":"replace","path":"/missing/field","value":1}]`, patch.ErrCannotApply},
+			{"Malformed", `{"op":"replace"}`, patch.ErrMalformed},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := patch.Apply(patch.JSONPatchType, doc, []byte(tc.operations))
+				if !errors.Is(err, tc.expected) {
+					t.Errorf("Expected %v, got %v", tc.expected, err)
+				}
+			})
+		}
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := patch.Apply("application/json", doc, []byte(`{}`))
+		if !errors.Is(err, patch.ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType, got %v", err)
+		}
+	})
+}
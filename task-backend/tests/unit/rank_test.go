@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/rank"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		lower, upper, expected string
+	}{
+		{"", "", "a0"},
+		{"a0", "", "a1"},
+		{"az", "", "b00"},
+		{"", "a0", "Zz"},
+		{"a0", "a1", "a0V"},
+		{"a0V", "a1", "a0l"},
+		{"a0", "a0V", "a0G"},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzz", "", "zzzzzzzzzzzzzzzzzzzzzzzzzzzV"},
+	}
+
+	for _, tt := range tests {
+		key, err := rank.Between(tt.lower, tt.upper)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): expected no error, got %v", tt.lower, tt.upper, err)
+		}
+		if key != tt.expected {
+			t.Errorf("Between(%q, %q): expected %q, got %q", tt.lower, tt.upper, tt.expected, key)
+		}
+	}
+
+	invalid := [][2]string{{"a1", "a0"}, {"a0", "a0"}, {"a10", ""}, {"0", ""}, {"a", ""}}
+	for _, bounds := range invalid {
+		if _, err := rank.Between(bounds[0], bounds[1]); err == nil {
+			t.Errorf("Between(%q, %q): expected error", bounds[0], bounds[1])
+		}
+	}
+}
+
+func TestRankRandomInserts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	keys := []string{}
+
+	for i := 0; i < 2000; i++ {
+		pos := rng.Intn(len(keys) + 1)
+		lower, upper := "", ""
+		if pos > 0 {
+			lower = keys[pos-1]
+		}
+		if pos < len(keys) {
+			upper = keys[pos]
+		}
+
+		key, err := rank.Between(lower, upper)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): expected no error, got %v", lower, upper, err)
+		}
+		if (lower != "" && key <= lower) || (upper != "" && key >= upper) {
+			t.Fatalf("Between(%q, %q): %q is out of order", lower, upper, key)
+		}
+
+		keys = append(keys[:pos], append([]string{key}, keys[pos:]...)...)
+	}
+
+	if !sort.StringsAreSorted(keys) {
+		t.Error("Expected keys to stay sorted")
+	}
+
+	// Menambah di akhir list tidak membuat key cepat memanjang
+	last := keys[len(keys)-1]
+	for i := 0; i < 10000; i++ {
+		next, err := rank.Between(last, "")
+		if err != nil || next <= last {
+			t.Fatalf("Between(%q, \"\"): got %q, %v", last, next, err)
+		}
+		last = next
+	}
+	if len(last) > 10 {
+		t.Errorf("Expected appended key to stay short, got %q", last)
+	}
+}
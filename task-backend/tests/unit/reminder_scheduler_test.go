@@ -0,0 +1,123 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/scheduler"
+	"github.com/Mahathirrr/task-management-backend/pkg/notifier"
+)
+
+// fakeReminderStore menyimpan task in-memory untuk ReminderScheduler
+type fakeReminderStore struct {
+	tasks []model.Task
+}
+
+func (f *fakeReminderStore) GetDueForReminder(dueAfter, dueBefore time.Time) ([]model.Task, error) {
+	var due []model.Task
+	for _, task := range f.tasks {
+		if task.DueAt != nil && task.DueAt.After(dueAfter) && !task.DueAt.After(dueBefore) && task.RemindedAt == nil && task.Status != model.TaskStatusCompleted {
+			due = append(due, task)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeReminderStore) MarkReminded(taskID int, remindedAt time.Time) error {
+	for i := range f.tasks {
+		if f.tasks[i].ID == taskID {
+			f.tasks[i].RemindedAt = &remindedAt
+		}
+	}
+	return nil
+}
+
+type fakeUserStore struct{}
+
+func (f *fakeUserStore) GetByID(id int) (*model.User, error) {
+	return &model.User{ID: id, Email: "user@example.com", Name: "User"}, nil
+}
+
+// fakeNotifier merekam reminder yang dikirim
+type fakeNotifier struct {
+	sent []notifier.Reminder
+	err  error
+}
+
+func (f *fakeNotifier) Notify(reminder notifier.Reminder) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, reminder)
+	return nil
+}
+
+func TestReminderScheduler(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(10 * time.Minute)
+	later := now.Add(2 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	newStore := func() *fakeReminderStore {
+		return &fakeReminderStore{tasks: []model.Task{
+			{ID: 1, UserID: 1, Title: "Due soon", Status: model.TaskStatusPending, DueAt: &soon},
+			{ID: 2, UserID: 1, Title: "Due later", Status: model.TaskStatusPending, DueAt: &later},
+			{ID: 3, UserID: 1, Title: "Completed", Status: model.TaskStatusCompleted, DueAt: &soon},
+			{ID: 4, UserID: 1, Title: "Overdue", Status: model.TaskStatusPending, DueAt: &past},
+		}}
+	}
+
+	t.Run("SendsRemindersWithinLeadTime", func(t *testing.T) {
+		store := newStore()
+		fake := &fakeNotifier{}
+		s := scheduler.NewReminderScheduler(store, &fakeUserStore{}, fake, 30*time.Minute, time.Minute)
+
+		sent, err := s.RunOnce(now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sent != 1 || len(fake.sent) != 1 {
+			t.Fatalf("Expected 1 reminder, got %d", len(fake.sent))
+		}
+		if fake.sent[0].TaskID != 1 {
+			t.Errorf("Expected reminder for task 1, got task %d", fake.sent[0].TaskID)
+		}
+		if fake.sent[0].UserEmail != "user@example.com" {
+			t.Errorf("Expected reminder to owner email, got %s", fake.sent[0].UserEmail)
+		}
+	})
+
+	t.Run("DoesNotRemindTwice", func(t *testing.T) {
+		store := newStore()
+		fake := &fakeNotifier{}
+		s := scheduler.NewReminderScheduler(store, &fakeUserStore{}, fake, 30*time.Minute, time.Minute)
+
+		s.RunOnce(now)
+		s.RunOnce(now.Add(time.Minute))
+
+		if len(fake.sent) != 1 {
+			t.Errorf("Expected 1 reminder after two runs, got %d", len(fake.sent))
+		}
+	})
+
+	t.Run("RetriesWhenNotifierFails", func(t *testing.T) {
+		store := newStore()
+		fake := &fakeNotifier{err: errors.New("smtp down")}
+		s := scheduler.NewReminderScheduler(store, &fakeUserStore{}, fake, 30*time.Minute, time.Minute)
+
+		sent, err := s.RunOnce(now)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("Expected 0 reminders sent, got %d", sent)
+		}
+
+		fake.err = nil
+		if sent, _ := s.RunOnce(now); sent != 1 {
+			t.Errorf("Expected reminder to be retried, got %d sent", sent)
+		}
+	})
+}
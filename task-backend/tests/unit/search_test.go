@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/pkg/search"
+)
+
+func TestSearchParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		boolean string
+		empty   bool
+	}{
+		{"Terms", "Fix Login", "+fix* +login*", false},
+		{"PhraseAndExclusion", `"release notes" -draft`, `+"release notes" -draft`, false},
+		{"ExcludedPhrase", `deploy -"staging server"`, `+deploy* -"staging server"`, false},
+		{"StripsOperators", "api+(v2)* <bug>", "+api* +bug*", false},
+		{"ShortTermsOnly", "ui", "", true},
+		{"ExclusionOnly", "-draft", "-draft", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := search.Parse(tt.input)
+			if got := q.BooleanMode(); got != tt.boolean {
+				t.Errorf("Expected %q, got %q", tt.boolean, got)
+			}
+			if q.IsEmpty() != tt.empty {
+				t.Errorf("Expected IsEmpty %v, got %v", tt.empty, q.IsEmpty())
+			}
+		})
+	}
+}
+
+func TestSearchHighlight(t *testing.T) {
+	t.Run("MarksPrefixMatchesAndEscapes", func(t *testing.T) {
+		got := search.Highlight("Deploying <app> to deploy", search.Parse("deploy"), 100)
+		want := "<mark>Deploying</mark> &lt;app&gt; to <mark>deploy</mark>"
+		if got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("TrimsAroundFirstMatch", func(t *testing.T) {
+		text := strings.Repeat("lorem ipsum ", 20) + "the release notes are ready " + strings.Repeat("dolor sit ", 20)
+		got := search.Highlight(text, search.Parse(`"release notes"`), 60)
+		if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") {
+			t.Errorf("Expected snippet to be trimmed on both sides, got %q", got)
+		}
+		if !strings.Contains(got, "<mark>release notes</mark>") {
+			t.Errorf("Expected phrase to be marked, got %q", got)
+		}
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		if got := search.Highlight("Nothing here", search.Parse("deploy"), 100); got != "" {
+			t.Errorf("Expected empty snippet, got %q", got)
+		}
+	})
+}
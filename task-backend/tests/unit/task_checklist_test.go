@@ -0,0 +1,75 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+)
+
+func TestToggleChecklistItem(t *testing.T) {
+	checked, unchecked := true, false
+	description := "Steps:\n\n- [ ] build\n- [ ] deploy\n"
+
+	newChecklistTask := func(t *testing.T) (*taskFixture, *model.Task) {
+		f := newTaskFixture()
+		assigneeID := 2
+		task, err := f.service().CreateTask(1, &model.TaskCreateRequest{Title: "Release", Description: &description, AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return f, task
+	}
+
+	t.Run("AssigneeCanCheck", func(t *testing.T) {
+		f, task := newChecklistTask(t)
+
+		updated, err := f.service().ToggleChecklistItem(task.ID, 1, 2, &model.TaskChecklistRequest{Checked: &checked}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "Steps:\n\n- [ ] build\n- [x] deploy\n"
+		if updated.Description == nil || *updated.Description != expected {
+			t.Errorf("Expected description %q, got %v", expected, updated.Description)
+		}
+		if updated.Version != task.Version+1 {
+			t.Errorf("Expected version %d, got %d", task.Version+1, updated.Version)
+		}
+	})
+
+	t.Run("UnchangedWhenAlreadyInState", func(t *testing.T) {
+		f, task := newChecklistTask(t)
+
+		updated, err := f.service().ToggleChecklistItem(task.ID, 0, 1, &model.TaskChecklistRequest{Checked: &unchecked}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.Version != task.Version {
+			t.Errorf("Expected version to stay %d, got %d", task.Version, updated.Version)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		f, task := newChecklistTask(t)
+		taskService := f.service()
+
+		tests := []struct {
+			name     string
+			index    int
+			userID   int
+			ifMatch  []int
+			expected string
+		}{
+			{"OtherUser", 0, 3, nil, model.ErrForbidden},
+			{"IndexOutOfRange", 2, 1, nil, model.ErrChecklistItemNotFound},
+			{"StaleVersion", 0, 1, []int{task.Version + 1}, model.ErrTaskModified},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := taskService.ToggleChecklistItem(task.ID, tt.index, tt.userID, &model.TaskChecklistRequest{Checked: &checked, IfMatch: tt.ifMatch}, false)
+				if err == nil || err.Error() != tt.expected {
+					t.Errorf("Expected %q error, got %v", tt.expected, err)
+				}
+			})
+		}
+	})
+}
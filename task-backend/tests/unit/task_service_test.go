@@ -1,26 +1,44 @@
 package unit
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/rank"
 )
 
 // Mock TaskRepository for testing
 type mockTaskRepository struct {
-	tasks  map[int]*model.Task
-	nextID int
+	tasks    map[int]*model.Task
+	blockers map[int][]int
+	nextID   int
+	locked   []int // id dari pemanggilan LockTasks terakhir
 }
 
 func newMockTaskRepository() *mockTaskRepository {
 	return &mockTaskRepository{
-		tasks:  make(map[int]*model.Task),
-		nextID: 1,
+		tasks:    make(map[int]*model.Task),
+		blockers: make(map[int][]int),
+		nextID:   1,
 	}
 }
 
 func (m *mockTaskRepository) Create(task *model.Task) error {
+	ranks := m.ranks(0, func(t *model.Task) bool { return t.UserID == task.UserID })
+	last := ""
+	if len(ranks) > 0 {
+		last = ranks[len(ranks)-1]
+	}
+	task.Rank, _ = rank.Between(last, "")
 	task.ID = m.nextID
+	task.Version = 1
 	m.nextID++
 	m.tasks[task.ID] = task
 	return nil
@@ -28,81 +46,1963 @@ func (m *mockTaskRepository) Create(task *model.Task) error {
 
 func (m *mockTaskRepository) GetByID(id int) (*model.Task, error) {
 	task, exists := m.tasks[id]
-	if !exists {
+	if !exists || task.DeletedAt != nil {
 		return nil, nil
 	}
-	return task, nil
+	// Salinan agar perubahan caller tidak tersimpan tanpa Update
+	copied := *task
+	copied.BlockedBy = append([]int{}, m.blockers[id]...)
+	return &copied, nil
 }
 
-func (m *mockTaskRepository) GetByUserID(userID int, page, limit int, status, search string) ([]model.Task, int, error) {
+func (m *mockTaskRepository) GetByUserID(userID int, page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error) {
 	var tasks []model.Task
 	for _, task := range m.tasks {
-		if task.UserID == userID {
-			if status != "" && string(task.Status) != status {
+		if task.UserID == userID && task.DeletedAt == nil {
+			if filter.Status != "" && string(task.Status) != filter.Status {
+				continue
+			}
+			if filter.DueAfter != nil && (task.DueAt == nil || !task.DueAt.After(*filter.DueAfter)) {
 				continue
 			}
 			tasks = append(tasks, *task)
 		}
 	}
-	return tasks, len(tasks), nil
+	return mockPage(tasks, page, filter), nil
 }
 
-func (m *mockTaskRepository) GetAll(page, limit int, status, search string) ([]model.Task, int, error) {
+func (m *mockTaskRepository) GetAll(page model.TaskPage, filter model.TaskFilter) (*model.TaskPageResult, error) {
 	var tasks []model.Task
 	for _, task := range m.tasks {
-		if status != "" && string(task.Status) != status {
+		if task.DeletedAt != nil || filter.Status != "" && string(task.Status) != filter.Status {
 			continue
 		}
 		tasks = append(tasks, *task)
 	}
-	return tasks, len(tasks), nil
+	return mockPage(tasks, page, filter), nil
+}
+
+// mockPage meniru pagination repository: urut (created_at, id), lalu
+// page/limit atau cursor
+func mockPage(tasks []model.Task, page model.TaskPage, filter model.TaskFilter) *model.TaskPageResult {
+	ascending := filter.Sort == model.TaskSortOldest
+	if page.Cursor != nil && page.Cursor.Backward {
+		ascending = !ascending
+	}
+	before := func(a, b model.Task) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt) == ascending
+		}
+		return a.ID != b.ID && (a.ID < b.ID) == ascending
+	}
+	sort.Slice(tasks, func(i, j int) bool { return before(tasks[i], tasks[j]) })
+
+	total := len(tasks)
+	result := &model.TaskPageResult{}
+	if page.IncludeTotal {
+		result.Total = &total
+	}
+
+	if page.Cursor == nil {
+		offset := (page.Page - 1) * page.Limit
+		if offset > len(tasks) {
+			offset = len(tasks)
+		}
+		tasks = tasks[offset:]
+	} else {
+		mark := model.Task{ID: page.Cursor.ID, CreatedAt: page.Cursor.CreatedAt}
+		var rest []model.Task
+		for _, task := range tasks {
+			if before(mark, task) {
+				rest = append(rest, task)
+			}
+		}
+		tasks = rest
+	}
+
+	hasMore := len(tasks) > page.Limit
+	if hasMore {
+		tasks = tasks[:page.Limit]
+	}
+
+	switch {
+	case page.Cursor == nil:
+		result.HasNext, result.HasPrev = hasMore, page.Page > 1
+	case page.Cursor.Backward:
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+		result.HasNext, result.HasPrev = true, hasMore
+	default:
+		result.HasNext, result.HasPrev = hasMore, true
+	}
+	result.Tasks = tasks
+
+	return result
 }
 
 func (m *mockTaskRepository) Update(task *model.Task) error {
-	m.tasks[task.ID] = task
+	stored, exists := m.tasks[task.ID]
+	if !exists || stored.Version != task.Version {
+		return repository.ErrVersionConflict
+	}
+	task.Version++
+	copied := *task
+	m.tasks[task.ID] = &copied
 	return nil
 }
 
-func (m *mockTaskRepository) Delete(id int) error {
-	delete(m.tasks, id)
+func (m *mockTaskRepository) SoftDelete(ids []int, deletedAt time.Time) error {
+	for _, id := range ids {
+		if task, exists := m.tasks[id]; exists {
+			task.DeletedAt = &deletedAt
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) Restore(id int, deletedAt time.Time) ([]int, error) {
+	m.tasks[id].DeletedAt = nil
+	restored := []int{id}
+	for childID, task := range m.tasks {
+		if task.ParentTaskID != nil && *task.ParentTaskID == id && task.DeletedAt != nil && task.DeletedAt.Equal(deletedAt) {
+			childIDs, _ := m.Restore(childID, deletedAt)
+			restored = append(restored, childIDs...)
+		}
+	}
+	return restored, nil
+}
+
+func (m *mockTaskRepository) GetDeletedByID(id int) (*model.Task, error) {
+	task, exists := m.tasks[id]
+	if !exists || task.DeletedAt == nil {
+		return nil, nil
+	}
+	return task, nil
+}
+
+func (m *mockTaskRepository) GetTrash(userID *int, page, limit int) ([]model.Task, int, error) {
+	var tasks []model.Task
+	for _, task := range m.tasks {
+		if task.DeletedAt == nil || userID != nil && task.UserID != *userID {
+			continue
+		}
+		if task.ParentTaskID != nil && m.tasks[*task.ParentTaskID].DeletedAt != nil {
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, len(tasks), nil
+}
+
+func (m *mockTaskRepository) GetTrashedBefore(deletedBefore time.Time) ([]int, error) {
+	var ids []int
+	for id, task := range m.tasks {
+		if task.DeletedAt != nil && task.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockTaskRepository) Purge(ids []int) error {
+	for _, id := range ids {
+		m.delete(id)
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) ApplyBulk(change *model.TaskBulkChange) error {
+	for _, id := range change.ReparentIDs {
+		m.ReparentSubtasks(id, m.tasks[id].ParentTaskID)
+	}
+	for _, id := range change.TaskIDs {
+		task := m.tasks[id]
+		switch change.Operation {
+		case model.BulkUpdateStatus:
+			task.Status = change.Status
+		case model.BulkMoveProject:
+			task.ProjectID = change.ProjectID
+		case model.BulkAddLabel:
+			task.Labels = append(task.Labels, model.Label{ID: change.LabelID})
+		case model.BulkDelete:
+			task.DeletedAt = &change.DeletedAt
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) LockTasks(ids []int) error {
+	m.locked = append([]int{}, ids...)
 	return nil
 }
 
+// delete meniru foreign key ON DELETE CASCADE pada parent_task_id
+func (m *mockTaskRepository) delete(id int) {
+	delete(m.tasks, id)
+	for childID, task := range m.tasks {
+		if task.ParentTaskID != nil && *task.ParentTaskID == id {
+			m.delete(childID)
+		}
+	}
+}
+
 func (m *mockTaskRepository) IsOwner(taskID, userID int) (bool, error) {
 	task, exists := m.tasks[taskID]
-	if !exists {
+	if !exists || task.DeletedAt != nil {
 		return false, nil
 	}
 	return task.UserID == userID, nil
 }
 
-func TestTaskValidation(t *testing.T) {
-	t.Run("ValidTaskCreateRequest", func(t *testing.T) {
-		req := model.TaskCreateRequest{
-			Title:  "Test Task",
-			Status: model.TaskStatusPending,
+func (m *mockTaskRepository) GetSubtasks(parentID int) ([]model.Task, error) {
+	var tasks []model.Task
+	for _, task := range m.tasks {
+		if task.ParentTaskID != nil && *task.ParentTaskID == parentID && task.DeletedAt == nil {
+			tasks = append(tasks, *task)
 		}
+	}
+	return tasks, nil
+}
 
-		if req.Title == "" {
-			t.Error("Expected title to be set")
+func (m *mockTaskRepository) ReparentSubtasks(parentID int, newParentID *int) error {
+	for _, task := range m.tasks {
+		if task.ParentTaskID != nil && *task.ParentTaskID == parentID {
+			task.ParentTaskID = newParentID
 		}
+	}
+	return nil
+}
 
-		if req.Status != model.TaskStatusPending {
-			t.Errorf("Expected status to be pending, got %s", req.Status)
+func (m *mockTaskRepository) GetDueForReminder(dueAfter, dueBefore time.Time) ([]model.Task, error) {
+	return nil, nil
+}
+
+func (m *mockTaskRepository) MarkReminded(taskID int, remindedAt time.Time) error {
+	return nil
+}
+
+func (m *mockTaskRepository) SetLabels(taskID int, labelIDs []int) error {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil
+	}
+	task.Labels = []model.Label{}
+	for _, id := range labelIDs {
+		task.Labels = append(task.Labels, model.Label{ID: id})
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) GetSeriesOccurrences(seriesID int, after time.Time) ([]model.Task, error) {
+	var tasks []model.Task
+	for _, task := range m.tasks {
+		if task.SeriesID != nil && *task.SeriesID == seriesID && task.OccurrenceAt.After(after) {
+			tasks = append(tasks, *task)
 		}
-	})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].OccurrenceAt.Before(*tasks[j].OccurrenceAt) })
+	return tasks, nil
+}
 
-	t.Run("TaskStatusConstants", func(t *testing.T) {
-		if model.TaskStatusPending != "pending" {
-			t.Errorf("Expected TaskStatusPending to be 'pending', got %s", model.TaskStatusPending)
+func (m *mockTaskRepository) AddDependency(taskID, blockerID int) error {
+	for _, id := range m.blockers[taskID] {
+		if id == blockerID {
+			return nil
 		}
+	}
+	m.blockers[taskID] = append(m.blockers[taskID], blockerID)
+	return nil
+}
 
-		if model.TaskStatusInProgress != "in_progress" {
-			t.Errorf("Expected TaskStatusInProgress to be 'in_progress', got %s", model.TaskStatusInProgress)
+func (m *mockTaskRepository) RemoveDependency(taskID, blockerID int) error {
+	var remaining []int
+	for _, id := range m.blockers[taskID] {
+		if id != blockerID {
+			remaining = append(remaining, id)
 		}
+	}
+	m.blockers[taskID] = remaining
+	return nil
+}
 
-		if model.TaskStatusCompleted != "completed" {
-			t.Errorf("Expected TaskStatusCompleted to be 'completed', got %s", model.TaskStatusCompleted)
+func (m *mockTaskRepository) GetBlockers(taskIDs []int) (map[int][]int, error) {
+	blockers := make(map[int][]int)
+	for _, taskID := range taskIDs {
+		blockers[taskID] = m.blockers[taskID]
+	}
+	return blockers, nil
+}
+
+func (m *mockTaskRepository) GetIncompleteBlockers(taskID int) ([]int, error) {
+	var ids []int
+	for _, id := range m.blockers[taskID] {
+		if blocker := m.tasks[id]; blocker.DeletedAt == nil && blocker.Status != model.TaskStatusCompleted {
+			ids = append(ids, id)
 		}
+	}
+	return ids, nil
+}
+
+func (m *mockTaskRepository) Move(move *model.TaskMove) error {
+	task, exists := m.tasks[move.TaskID]
+	if !exists || task.DeletedAt != nil || task.Version != move.Version {
+		return repository.ErrVersionConflict
+	}
+
+	neighbourRank := func(id *int) (string, error) {
+		if id == nil {
+			return "", nil
+		}
+		neighbour, exists := m.tasks[*id]
+		if !exists || neighbour.DeletedAt != nil || neighbour.Status != move.Status {
+			return "", repository.ErrRankConflict
+		}
+		return neighbour.Rank, nil
+	}
+	lower, err := neighbourRank(move.BeforeID)
+	if err != nil {
+		return err
+	}
+	upper, err := neighbourRank(move.AfterID)
+	if err != nil {
+		return err
+	}
+	if lower != "" && upper != "" && lower >= upper {
+		return repository.ErrRankConflict
+	}
+
+	// Sama seperti repository: batas kosong dipersempit ke rank terdekat
+	// milik pemilik task dan rank terdekat di kolom tujuan
+	adjacent := func(ranks []string, from string, next bool) string {
+		if next {
+			if i := sort.Search(len(ranks), func(i int) bool { return ranks[i] > from }); i < len(ranks) {
+				return ranks[i]
+			}
+			return ""
+		}
+		if from == "" {
+			from = "~"
+		}
+		if i := sort.SearchStrings(ranks, from); i > 0 {
+			return ranks[i-1]
+		}
+		return ""
+	}
+	ownerRanks := m.ranks(move.TaskID, func(t *model.Task) bool { return t.UserID == task.UserID })
+	columnRanks := m.ranks(move.TaskID, func(t *model.Task) bool { return t.Status == move.Status && t.DeletedAt == nil })
+	next := move.BeforeID != nil
+	from := upper
+	if next {
+		from = lower
+	}
+	for _, r := range []string{adjacent(ownerRanks, from, next), adjacent(columnRanks, from, next)} {
+		if next && r != "" && (upper == "" || r < upper) {
+			upper = r
+		}
+		if !next && r > lower {
+			lower = r
+		}
+	}
+
+	newRank, err := rank.Between(lower, upper)
+	if err != nil {
+		return err
+	}
+	task.Status = move.Status
+	task.Rank = newRank
+	task.Version++
+	return nil
+}
+
+func (m *mockTaskRepository) GetBoardColumn(userID *int, status model.TaskStatus, filter model.TaskFilter, limit int) ([]model.Task, bool, error) {
+	var tasks []model.Task
+	for _, task := range m.tasks {
+		if task.DeletedAt == nil && task.Status == status && mockBoardVisible(task, userID) {
+			tasks = append(tasks, *task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Rank != tasks[j].Rank {
+			return tasks[i].Rank < tasks[j].Rank
+		}
+		return tasks[i].ID < tasks[j].ID
 	})
-}
\ No newline at end of file
+	if len(tasks) > limit {
+		return tasks[:limit], true, nil
+	}
+	return tasks, false, nil
+}
+
+func (m *mockTaskRepository) GetBoardStatuses(userID *int, filter model.TaskFilter) ([]model.TaskStatus, error) {
+	seen := map[model.TaskStatus]bool{}
+	var statuses []model.TaskStatus
+	for _, task := range m.tasks {
+		if task.DeletedAt == nil && mockBoardVisible(task, userID) && !seen[task.Status] {
+			seen[task.Status] = true
+			statuses = append(statuses, task.Status)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses, nil
+}
+
+// ranks mengembalikan rank semua task terurut, tanpa task excludeID
+func (m *mockTaskRepository) ranks(excludeID int, include func(task *model.Task) bool) []string {
+	var ranks []string
+	for id, task := range m.tasks {
+		if id != excludeID && include(task) {
+			ranks = append(ranks, task.Rank)
+		}
+	}
+	sort.Strings(ranks)
+	return ranks
+}
+
+func mockBoardVisible(task *model.Task, userID *int) bool {
+	return userID == nil || task.UserID == *userID || task.AssigneeID != nil && *task.AssigneeID == *userID
+}
+
+// Mock SeriesRepository for testing
+type mockSeriesRepository struct {
+	series map[int]*model.TaskSeries
+	nextID int
+}
+
+func newMockSeriesRepository() *mockSeriesRepository {
+	return &mockSeriesRepository{
+		series: make(map[int]*model.TaskSeries),
+		nextID: 1,
+	}
+}
+
+func (m *mockSeriesRepository) Create(series *model.TaskSeries) error {
+	series.ID = m.nextID
+	m.nextID++
+	m.series[series.ID] = series
+	return nil
+}
+
+func (m *mockSeriesRepository) GetByID(id int) (*model.TaskSeries, error) {
+	series, exists := m.series[id]
+	if !exists {
+		return nil, nil
+	}
+	return series, nil
+}
+
+func (m *mockSeriesRepository) Update(series *model.TaskSeries) error {
+	m.series[series.ID] = series
+	return nil
+}
+
+// Mock LabelRepository for testing
+type mockLabelRepository struct {
+	labels map[int]*model.Label
+	nextID int
+}
+
+func newMockLabelRepository() *mockLabelRepository {
+	return &mockLabelRepository{
+		labels: make(map[int]*model.Label),
+		nextID: 1,
+	}
+}
+
+func (m *mockLabelRepository) Create(label *model.Label) error {
+	label.ID = m.nextID
+	m.nextID++
+	m.labels[label.ID] = label
+	return nil
+}
+
+func (m *mockLabelRepository) GetByID(id int) (*model.Label, error) {
+	return m.labels[id], nil
+}
+
+func (m *mockLabelRepository) GetByIDs(ids []int) ([]model.Label, error) {
+	var labels []model.Label
+	for _, id := range ids {
+		if label, exists := m.labels[id]; exists {
+			labels = append(labels, *label)
+		}
+	}
+	return labels, nil
+}
+
+func (m *mockLabelRepository) GetByName(userID int, name string) (*model.Label, error) {
+	for _, label := range m.labels {
+		if label.UserID == userID && label.Name == name {
+			return label, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockLabelRepository) GetByUserID(userID int) ([]model.Label, error) {
+	var labels []model.Label
+	for _, label := range m.labels {
+		if label.UserID == userID {
+			labels = append(labels, *label)
+		}
+	}
+	return labels, nil
+}
+
+func (m *mockLabelRepository) Update(label *model.Label) error {
+	m.labels[label.ID] = label
+	return nil
+}
+
+func (m *mockLabelRepository) Delete(id int) error {
+	delete(m.labels, id)
+	return nil
+}
+
+// Mock ProjectRepository for testing
+type mockProjectRepository struct {
+	projects map[int]*model.Project
+	nextID   int
+}
+
+func newMockProjectRepository() *mockProjectRepository {
+	return &mockProjectRepository{
+		projects: make(map[int]*model.Project),
+		nextID:   1,
+	}
+}
+
+func (m *mockProjectRepository) Create(project *model.Project) error {
+	project.ID = m.nextID
+	m.nextID++
+	m.projects[project.ID] = project
+	return nil
+}
+
+func (m *mockProjectRepository) GetByID(id int) (*model.Project, error) {
+	return m.projects[id], nil
+}
+
+func (m *mockProjectRepository) GetByUserID(userID int, includeArchived bool) ([]model.Project, error) {
+	var projects []model.Project
+	for _, project := range m.projects {
+		if project.UserID == userID && (includeArchived || !project.Archived) {
+			projects = append(projects, *project)
+		}
+	}
+	return projects, nil
+}
+
+func (m *mockProjectRepository) Update(project *model.Project) error {
+	m.projects[project.ID] = project
+	return nil
+}
+
+func (m *mockProjectRepository) Delete(id int) error {
+	delete(m.projects, id)
+	return nil
+}
+
+// Mock UserRepository for testing
+type mockUserRepository struct {
+	users map[int]*model.User
+}
+
+func newMockUserRepository() *mockUserRepository {
+	return &mockUserRepository{
+		users: map[int]*model.User{
+			1: {ID: 1, Email: "owner@example.com", Name: "Owner"},
+			2: {ID: 2, Email: "assignee@example.com", Name: "Assignee"},
+			3: {ID: 3, Email: "other@example.com", Name: "Other"},
+		},
+	}
+}
+
+func (m *mockUserRepository) Create(user *model.User) error {
+	user.ID = len(m.users) + 1
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepository) GetByEmail(email string) (*model.User, error) {
+	for _, user := range m.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockUserRepository) GetByID(id int) (*model.User, error) {
+	return m.users[id], nil
+}
+
+func (m *mockUserRepository) GetByOAuth(provider, oauthID string) (*model.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepository) GetAll(page, limit int) ([]model.User, int, error) {
+	var users []model.User
+	for _, user := range m.users {
+		users = append(users, *user)
+	}
+	return users, len(users), nil
+}
+
+func (m *mockUserRepository) Update(user *model.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *mockUserRepository) Delete(id int) error {
+	delete(m.users, id)
+	return nil
+}
+
+// Mock ActivityRepository for testing
+type mockActivityRepository struct {
+	activities []model.TaskActivity
+	err        error // dikembalikan Create untuk menguji rollback
+}
+
+func (m *mockActivityRepository) Create(activities []model.TaskActivity) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.activities = append(m.activities, activities...)
+	return nil
+}
+
+func (m *mockActivityRepository) GetByTaskID(taskID int, page, limit int) ([]model.TaskActivity, int, error) {
+	var activities []model.TaskActivity
+	for i := len(m.activities) - 1; i >= 0; i-- {
+		if m.activities[i].TaskID == taskID {
+			activities = append(activities, m.activities[i])
+		}
+	}
+	return activities, len(activities), nil
+}
+
+// mockTransactor memakai mock repositories yang sama dan meniru rollback
+// dengan mengembalikan task dan activity ke kondisi sebelum transaksi
+type mockTransactor struct {
+	taskRepo     *mockTaskRepository
+	activityRepo *mockActivityRepository
+	seriesRepo   *mockSeriesRepository
+	count        int // jumlah transaksi yang dimulai
+}
+
+func (m *mockTransactor) WithinTx(fn func(repos repository.TxRepositories) error) error {
+	m.count++
+	tasks := make(map[int]*model.Task, len(m.taskRepo.tasks))
+	for id, task := range m.taskRepo.tasks {
+		copied := *task
+		tasks[id] = &copied
+	}
+	nextID, activities := m.taskRepo.nextID, len(m.activityRepo.activities)
+	series := make(map[int]*model.TaskSeries, len(m.seriesRepo.series))
+	for id, s := range m.seriesRepo.series {
+		series[id] = s
+	}
+
+	err := fn(repository.TxRepositories{Tasks: m.taskRepo, Activities: m.activityRepo, Series: m.seriesRepo})
+	if err != nil {
+		m.taskRepo.tasks, m.taskRepo.nextID = tasks, nextID
+		m.activityRepo.activities = m.activityRepo.activities[:activities]
+		m.seriesRepo.series = series
+	}
+	return err
+}
+
+// taskFixture menyimpan mock repositories yang dipakai TaskService
+type taskFixture struct {
+	taskRepo       *mockTaskRepository
+	labelRepo      *mockLabelRepository
+	projectRepo    *mockProjectRepository
+	userRepo       *mockUserRepository
+	attachmentRepo *mockAttachmentRepository
+	fileStorage    *memoryStorage
+	activityRepo   *mockActivityRepository
+	workflowRepo   *mockWorkflowRepository
+	seriesRepo     *mockSeriesRepository
+	transactor     *mockTransactor
+}
+
+func newTaskFixture() *taskFixture {
+	f := &taskFixture{
+		taskRepo:       newMockTaskRepository(),
+		labelRepo:      newMockLabelRepository(),
+		projectRepo:    newMockProjectRepository(),
+		userRepo:       newMockUserRepository(),
+		attachmentRepo: newMockAttachmentRepository(),
+		fileStorage:    newMemoryStorage(),
+		activityRepo:   &mockActivityRepository{},
+		workflowRepo:   newMockWorkflowRepository(),
+		seriesRepo:     newMockSeriesRepository(),
+	}
+	f.transactor = &mockTransactor{taskRepo: f.taskRepo, activityRepo: f.activityRepo, seriesRepo: f.seriesRepo}
+	return f
+}
+
+func (f *taskFixture) service() service.TaskService {
+	return service.NewTaskService(f.taskRepo, f.labelRepo, f.projectRepo, f.userRepo, f.attachmentRepo, f.fileStorage, f.activityRepo, f.workflowRepo, f.seriesRepo, f.transactor)
+}
+
+func TestTaskValidation(t *testing.T) {
+	t.Run("ValidTaskCreateRequest", func(t *testing.T) {
+		req := model.TaskCreateRequest{
+			Title:  "Test Task",
+			Status: model.TaskStatusPending,
+		}
+
+		if req.Title == "" {
+			t.Error("Expected title to be set")
+		}
+
+		if req.Status != model.TaskStatusPending {
+			t.Errorf("Expected status to be pending, got %s", req.Status)
+		}
+	})
+
+	t.Run("TaskStatusConstants", func(t *testing.T) {
+		if model.TaskStatusPending != "pending" {
+			t.Errorf("Expected TaskStatusPending to be 'pending', got %s", model.TaskStatusPending)
+		}
+
+		if model.TaskStatusInProgress != "in_progress" {
+			t.Errorf("Expected TaskStatusInProgress to be 'in_progress', got %s", model.TaskStatusInProgress)
+		}
+
+		if model.TaskStatusCompleted != "completed" {
+			t.Errorf("Expected TaskStatusCompleted to be 'completed', got %s", model.TaskStatusCompleted)
+		}
+	})
+}
+
+func TestSubtasks(t *testing.T) {
+	t.Run("SubtaskBelongsToParentOwner", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		parent, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Parent"})
+
+		// Admin membuat subtask, pemiliknya tetap pemilik parent
+		subtask, err := taskService.CreateSubtask(parent.ID, 99, &model.TaskCreateRequest{Title: "Child"}, true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if subtask.UserID != parent.UserID {
+			t.Errorf("Expected subtask owner %d, got %d", parent.UserID, subtask.UserID)
+		}
+		if subtask.ParentTaskID == nil || *subtask.ParentTaskID != parent.ID {
+			t.Errorf("Expected subtask parent to be %d", parent.ID)
+		}
+	})
+
+	t.Run("OtherUserCannotCreateSubtask", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		parent, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Parent"})
+
+		_, err := taskService.CreateSubtask(parent.ID, 2, &model.TaskCreateRequest{Title: "Child"}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("DepthLimit", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		current, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Root"})
+		for depth := 2; depth <= model.MaxTaskDepth; depth++ {
+			child, err := taskService.CreateSubtask(current.ID, 1, &model.TaskCreateRequest{Title: "Child"}, false)
+			if err != nil {
+				t.Fatalf("Expected subtask at depth %d, got %v", depth, err)
+			}
+			current = child
+		}
+
+		_, err := taskService.CreateSubtask(current.ID, 1, &model.TaskCreateRequest{Title: "Too deep"}, false)
+		if err == nil || err.Error() != model.ErrMaxTaskDepth {
+			t.Errorf("Expected %q error, got %v", model.ErrMaxTaskDepth, err)
+		}
+	})
+
+	t.Run("DeleteReparentsSubtasks", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		root, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Root"})
+		middle, _ := taskService.CreateSubtask(root.ID, 1, &model.TaskCreateRequest{Title: "Middle"}, false)
+		leaf, _ := taskService.CreateSubtask(middle.ID, 1, &model.TaskCreateRequest{Title: "Leaf"}, false)
+
+		if err := taskService.DeleteTask(middle.ID, 1, false, model.SubtaskDeleteReparent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		moved, _ := f.taskRepo.GetByID(leaf.ID)
+		if moved == nil || moved.ParentTaskID == nil || *moved.ParentTaskID != root.ID {
+			t.Errorf("Expected leaf to be reparented to root %d", root.ID)
+		}
+	})
+
+	t.Run("DeleteCascadesSubtasks", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		root, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Root"})
+		child, _ := taskService.CreateSubtask(root.ID, 1, &model.TaskCreateRequest{Title: "Child"}, false)
+
+		if err := taskService.DeleteTask(root.ID, 1, false, model.SubtaskDeleteCascade); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if deleted, _ := f.taskRepo.GetByID(child.ID); deleted != nil {
+			t.Error("Expected child to be deleted with its parent")
+		}
+	})
+}
+
+func TestTaskLabels(t *testing.T) {
+	t.Run("LabelsMustBelongToOwner", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		own := &model.Label{UserID: 1, Name: "bug", Color: "#ff0000"}
+		other := &model.Label{UserID: 2, Name: "bug", Color: "#ff0000"}
+		f.labelRepo.Create(own)
+		f.labelRepo.Create(other)
+
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", LabelIDs: []int{own.ID}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(task.Labels) != 1 || task.Labels[0].ID != own.ID {
+			t.Errorf("Expected task to have label %d, got %v", own.ID, task.Labels)
+		}
+
+		_, err = taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", LabelIDs: []int{other.ID}})
+		if err == nil || err.Error() != model.ErrInvalidLabels {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidLabels, err)
+		}
+	})
+
+	t.Run("DuplicateLabelName", func(t *testing.T) {
+		labelService := service.NewLabelService(newMockLabelRepository())
+
+		if _, err := labelService.CreateLabel(1, &model.LabelCreateRequest{Name: "backend", Color: "#00ff00"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err := labelService.CreateLabel(1, &model.LabelCreateRequest{Name: "backend", Color: "#0000ff"})
+		if err == nil || err.Error() != model.ErrLabelAlreadyExists {
+			t.Errorf("Expected %q error, got %v", model.ErrLabelAlreadyExists, err)
+		}
+	})
+}
+
+func TestTaskProjects(t *testing.T) {
+	t.Run("ProjectMustBelongToOwner", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		other := &model.Project{UserID: 2, Name: "Other"}
+		f.projectRepo.Create(other)
+
+		_, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", ProjectID: &other.ID})
+		if err == nil || err.Error() != model.ErrInvalidProject {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidProject, err)
+		}
+	})
+
+	t.Run("RemoveTaskFromProject", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		project := &model.Project{UserID: 1, Name: "Mine"}
+		f.projectRepo.Create(project)
+
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", ProjectID: &project.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		noProject := 0
+		updated, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{ProjectID: &noProject}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.ProjectID != nil {
+			t.Errorf("Expected task to be removed from project, got project %d", *updated.ProjectID)
+		}
+	})
+
+	t.Run("SubtaskInheritsProject", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		project := &model.Project{UserID: 1, Name: "Mine"}
+		f.projectRepo.Create(project)
+
+		parent, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Parent", ProjectID: &project.ID})
+		subtask, err := taskService.CreateSubtask(parent.ID, 1, &model.TaskCreateRequest{Title: "Child"}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if subtask.ProjectID == nil || *subtask.ProjectID != project.ID {
+			t.Errorf("Expected subtask to inherit project %d", project.ID)
+		}
+	})
+}
+
+func TestTaskAssignee(t *testing.T) {
+	newAssignedTask := func(t *testing.T) (service.TaskService, *model.Task) {
+		taskService := newTaskFixture().service()
+
+		assigneeID := 2
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return taskService, task
+	}
+
+	t.Run("AssigneeMustExist", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+
+		missing := 42
+		_, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &missing})
+		if err == nil || err.Error() != model.ErrAssigneeNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrAssigneeNotFound, err)
+		}
+	})
+
+	t.Run("AssigneeCanReadAndChangeStatus", func(t *testing.T) {
+		taskService, task := newAssignedTask(t)
+
+		if _, err := taskService.GetTaskByID(task.ID, 2, false); err != nil {
+			t.Errorf("Expected assignee to read task, got %v", err)
+		}
+
+		status := model.TaskStatusInProgress
+		updated, err := taskService.UpdateTask(task.ID, 2, &model.TaskUpdateRequest{Status: &status}, false)
+		if err != nil {
+			t.Fatalf("Expected assignee to change status, got %v", err)
+		}
+		if updated.Status != model.TaskStatusInProgress {
+			t.Errorf("Expected status %s, got %s", model.TaskStatusInProgress, updated.Status)
+		}
+	})
+
+	t.Run("AssigneeCannotEditOrDelete", func(t *testing.T) {
+		taskService, task := newAssignedTask(t)
+
+		title := "Renamed"
+		_, err := taskService.UpdateTask(task.ID, 2, &model.TaskUpdateRequest{Title: &title}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error on edit, got %v", model.ErrForbidden, err)
+		}
+
+		err = taskService.DeleteTask(task.ID, 2, false, model.SubtaskDeleteReparent)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error on delete, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("OtherUserCannotRead", func(t *testing.T) {
+		taskService, task := newAssignedTask(t)
+
+		_, err := taskService.GetTaskByID(task.ID, 3, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+	})
+}
+
+func TestTaskActivity(t *testing.T) {
+	f := newTaskFixture()
+	taskService := f.service()
+
+	assigneeID := 2
+	task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	status := model.TaskStatusCompleted
+	if _, err := taskService.UpdateTask(task.ID, 2, &model.TaskUpdateRequest{Status: &status}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	title := "Task"
+	pending := model.TaskStatusPending
+	if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &title, Status: &pending}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resp, err := taskService.GetTaskActivity(task.ID, 1, 1, 10, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Judul yang tidak berubah tidak dicatat, jadi hanya ada created + dua perubahan status
+	if resp.Total != 3 {
+		t.Fatalf("Expected 3 activities, got %d", resp.Total)
+	}
+
+	latest := resp.Activities[0]
+	if latest.Action != model.ActivityUpdated || *latest.Field != "status" || *latest.UserID != 1 {
+		t.Errorf("Expected status update by user 1, got %+v", latest)
+	}
+	if *latest.OldValue != string(model.TaskStatusCompleted) || *latest.NewValue != string(model.TaskStatusPending) {
+		t.Errorf("Expected completed -> pending, got %s -> %s", *latest.OldValue, *latest.NewValue)
+	}
+	if resp.Activities[2].Action != model.ActivityCreated {
+		t.Errorf("Expected first activity to be %s, got %s", model.ActivityCreated, resp.Activities[2].Action)
+	}
+
+	if err := taskService.DeleteTask(task.ID, 1, false, model.SubtaskDeleteReparent); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	deleted := f.activityRepo.activities[len(f.activityRepo.activities)-1]
+	if deleted.Action != model.ActivityDeleted || deleted.TaskID != task.ID {
+		t.Errorf("Expected delete activity for task %d, got %+v", task.ID, deleted)
+	}
+}
+
+func TestTaskChangesRollback(t *testing.T) {
+	f := newTaskFixture()
+	taskService := f.service()
+
+	task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	f.activityRepo.err = errors.New("activity insert failed")
+	title := "Renamed"
+	if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &title}, false); err == nil {
+		t.Fatal("Expected error when activity cannot be recorded")
+	}
+
+	stored := f.taskRepo.tasks[task.ID]
+	if stored.Title != "Task" || stored.Version != task.Version {
+		t.Errorf("Expected update to be rolled back, got title %q version %d", stored.Title, stored.Version)
+	}
+
+	if _, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Orphan"}); err == nil {
+		t.Fatal("Expected error when activity cannot be recorded")
+	}
+	if len(f.taskRepo.tasks) != 1 {
+		t.Errorf("Expected create to be rolled back, got %d tasks", len(f.taskRepo.tasks))
+	}
+
+	f.activityRepo.err = nil
+	subtask, err := taskService.CreateSubtask(task.ID, 1, &model.TaskCreateRequest{Title: "Subtask"}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	f.activityRepo.err = errors.New("activity insert failed")
+	if err := taskService.DeleteTask(task.ID, 1, false, model.SubtaskDeleteReparent); err == nil {
+		t.Fatal("Expected error when activity cannot be recorded")
+	}
+	if stored := f.taskRepo.tasks[subtask.ID]; stored.ParentTaskID == nil || *stored.ParentTaskID != task.ID {
+		t.Errorf("Expected reparent to be rolled back, got parent %v", stored.ParentTaskID)
+	}
+	if f.taskRepo.tasks[task.ID].DeletedAt != nil {
+		t.Error("Expected delete to be rolled back")
+	}
+
+	rank := f.taskRepo.tasks[task.ID].Rank
+	if _, err := taskService.MoveTask(task.ID, 1, &model.TaskMoveRequest{Status: model.TaskStatusInProgress}, false); err == nil {
+		t.Fatal("Expected error when activity cannot be recorded")
+	}
+	if stored := f.taskRepo.tasks[task.ID]; stored.Status != model.TaskStatusPending || stored.Rank != rank {
+		t.Errorf("Expected move to be rolled back, got status %s rank %s", stored.Status, stored.Rank)
+	}
+}
+
+func TestRecurringTasks(t *testing.T) {
+	dueAt := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	newRecurringTask := func(t *testing.T, taskService service.TaskService) *model.Task {
+		rule := "FREQ=WEEKLY;BYDAY=MO"
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Weekly report", DueAt: &dueAt, RecurrenceRule: &rule})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return task
+	}
+
+	t.Run("InvalidRule", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+
+		rule := "FREQ=SOMETIMES"
+		_, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", DueAt: &dueAt, RecurrenceRule: &rule})
+		if err == nil || err.Error() != model.ErrInvalidRecurrence {
+			t.Errorf("Expected error %s, got %v", model.ErrInvalidRecurrence, err)
+		}
+	})
+
+	t.Run("RequiresDueDate", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+
+		rule := "FREQ=DAILY"
+		_, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", RecurrenceRule: &rule})
+		if err == nil || err.Error() != model.ErrRecurrenceRequiresDueDate {
+			t.Errorf("Expected error %s, got %v", model.ErrRecurrenceRequiresDueDate, err)
+		}
+	})
+
+	t.Run("CompletingCreatesNextOccurrence", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task := newRecurringTask(t, taskService)
+
+		status := model.TaskStatusCompleted
+		if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &status}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		occurrences, _ := f.taskRepo.GetSeriesOccurrences(*task.SeriesID, dueAt)
+		if len(occurrences) != 1 {
+			t.Fatalf("Expected 1 next occurrence, got %d", len(occurrences))
+		}
+		next := occurrences[0]
+		if !next.DueAt.Equal(dueAt.AddDate(0, 0, 7)) || next.Status != model.TaskStatusPending || next.Title != task.Title {
+			t.Errorf("Expected pending copy due a week later, got %+v", next)
+		}
+
+		// Menyelesaikan ulang tidak membuat occurrence ganda
+		pending := model.TaskStatusPending
+		taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &pending}, false)
+		taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &status}, false)
+		occurrences, _ = f.taskRepo.GetSeriesOccurrences(*task.SeriesID, dueAt)
+		if len(occurrences) != 1 {
+			t.Errorf("Expected still 1 next occurrence, got %d", len(occurrences))
+		}
+	})
+
+	t.Run("GenerateOccurrences", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		task := newRecurringTask(t, taskService)
+
+		occurrences, err := taskService.GenerateOccurrences(task.ID, 1, 3, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(occurrences) != 3 || !occurrences[2].DueAt.Equal(dueAt.AddDate(0, 0, 21)) {
+			t.Fatalf("Expected 3 weekly occurrences, got %+v", occurrences)
+		}
+
+		// Generate berikutnya melanjutkan dari occurrence terakhir
+		more, err := taskService.GenerateOccurrences(task.ID, 1, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(more) != 1 || !more[0].DueAt.Equal(dueAt.AddDate(0, 0, 28)) {
+			t.Errorf("Expected occurrence 4 weeks later, got %+v", more)
+		}
+
+		plain, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Plain"})
+		_, err = taskService.GenerateOccurrences(plain.ID, 1, 1, false)
+		if err == nil || err.Error() != model.ErrTaskNotRecurring {
+			t.Errorf("Expected error %s, got %v", model.ErrTaskNotRecurring, err)
+		}
+	})
+
+	t.Run("FailedUpdateLeavesNoSeries", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", DueAt: &dueAt})
+
+		f.activityRepo.err = errors.New("activity store unavailable")
+		rule := "FREQ=DAILY"
+		if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{RecurrenceRule: &rule}, false); err == nil {
+			t.Fatal("Expected error when activity cannot be recorded")
+		}
+		if len(f.seriesRepo.series) != 0 {
+			t.Errorf("Expected no series to be kept, got %d", len(f.seriesRepo.series))
+		}
+	})
+
+	t.Run("GenerateOccurrencesRollsBack", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task := newRecurringTask(t, taskService)
+		tasks := len(f.taskRepo.tasks)
+
+		f.activityRepo.err = errors.New("activity store unavailable")
+		if _, err := taskService.GenerateOccurrences(task.ID, 1, 3, false); err == nil {
+			t.Fatal("Expected error when activity cannot be recorded")
+		}
+		if len(f.taskRepo.tasks) != tasks {
+			t.Errorf("Expected no occurrence to be kept, got %d tasks", len(f.taskRepo.tasks))
+		}
+	})
+
+	t.Run("UpdateThisRejectsRuleChange", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		task := newRecurringTask(t, taskService)
+
+		rule := "FREQ=DAILY"
+		_, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{RecurrenceRule: &rule}, false)
+		if err == nil || err.Error() != model.ErrRecurrenceScope {
+			t.Errorf("Expected error %s, got %v", model.ErrRecurrenceScope, err)
+		}
+	})
+
+	t.Run("UpdateFuturePropagatesFields", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task := newRecurringTask(t, taskService)
+		occurrences, _ := taskService.GenerateOccurrences(task.ID, 1, 2, false)
+
+		title := "Weekly summary"
+		count := f.transactor.count
+		if _, err := taskService.UpdateTaskSeries(occurrences[0].ID, 1, &model.TaskUpdateRequest{Title: &title}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if f.transactor.count != count+1 {
+			t.Errorf("Expected series update in one transaction, got %d", f.transactor.count-count)
+		}
+
+		if first, _ := f.taskRepo.GetByID(task.ID); first.Title != "Weekly report" {
+			t.Errorf("Expected earlier occurrence unchanged, got %s", first.Title)
+		}
+		for _, occurrence := range occurrences {
+			if updated, _ := f.taskRepo.GetByID(occurrence.ID); updated.Title != title {
+				t.Errorf("Expected occurrence %d title %s, got %s", occurrence.ID, title, updated.Title)
+			}
+		}
+	})
+
+	t.Run("UpdateFutureSplitsSeries", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task := newRecurringTask(t, taskService)
+		occurrences, _ := taskService.GenerateOccurrences(task.ID, 1, 3, false)
+		oldSeriesID := *task.SeriesID
+
+		rule := "FREQ=DAILY"
+		updated, err := taskService.UpdateTaskSeries(occurrences[0].ID, 1, &model.TaskUpdateRequest{RecurrenceRule: &rule}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.SeriesID == nil || *updated.SeriesID == oldSeriesID {
+			t.Fatalf("Expected occurrence to start a new series, got %v", updated.SeriesID)
+		}
+
+		// Occurrence lama setelah split masuk trash, series lama berakhir sebelum split
+		leftover, _ := f.taskRepo.GetSeriesOccurrences(oldSeriesID, dueAt)
+		for _, occurrence := range leftover {
+			if occurrence.DeletedAt == nil {
+				t.Errorf("Expected occurrence %d in old series to be trashed", occurrence.ID)
+			}
+		}
+		if first, _ := f.taskRepo.GetByID(task.ID); first == nil || *first.SeriesID != oldSeriesID {
+			t.Errorf("Expected first occurrence to stay in old series")
+		}
+		oldSeries, _ := f.seriesRepo.GetByID(oldSeriesID)
+		if oldSeries.Rule == "FREQ=WEEKLY;BYDAY=MO" {
+			t.Errorf("Expected old series to be ended, got %s", oldSeries.Rule)
+		}
+
+		next, err := taskService.GenerateOccurrences(updated.ID, 1, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !next[0].DueAt.Equal(updated.DueAt.AddDate(0, 0, 1)) {
+			t.Errorf("Expected daily occurrence, got %v", next[0].DueAt)
+		}
+	})
+
+	t.Run("UpdateFutureStopsRecurrence", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		task := newRecurringTask(t, taskService)
+
+		empty := ""
+		updated, err := taskService.UpdateTaskSeries(task.ID, 1, &model.TaskUpdateRequest{RecurrenceRule: &empty}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.SeriesID != nil || updated.RecurrenceRule != nil {
+			t.Errorf("Expected task to stop recurring, got series %v", updated.SeriesID)
+		}
+	})
+}
+
+func TestTaskTrash(t *testing.T) {
+	t.Run("DeletedTaskMovesToTrash", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+
+		if err := taskService.DeleteTask(task.ID, 1, false, model.SubtaskDeleteReparent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, err := taskService.GetTaskByID(task.ID, 1, false); err == nil || err.Error() != model.ErrTaskNotFound {
+			t.Errorf("Expected error %s, got %v", model.ErrTaskNotFound, err)
+		}
+		list, _ := taskService.GetUserTasks(1, model.TaskPage{Page: 1, Limit: 10, IncludeTotal: true}, model.TaskFilter{})
+		if *list.Total != 0 {
+			t.Errorf("Expected trashed task to be excluded from list, got %d", *list.Total)
+		}
+
+		trash, err := taskService.GetTrash(1, 1, 10, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if *trash.Total != 1 || trash.Tasks[0].ID != task.ID || trash.Tasks[0].DeletedAt == nil {
+			t.Errorf("Expected task in trash, got %+v", trash.Tasks)
+		}
+		if other, _ := taskService.GetTrash(2, 1, 10, false); *other.Total != 0 {
+			t.Errorf("Expected other user's trash to be empty, got %d", *other.Total)
+		}
+	})
+
+	t.Run("RestoreBringsBackCascadedSubtasks", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		parent, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Parent"})
+		child, _ := taskService.CreateSubtask(parent.ID, 1, &model.TaskCreateRequest{Title: "Child"}, false)
+
+		if err := taskService.DeleteTask(parent.ID, 1, false, model.SubtaskDeleteCascade); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Subtask yang ikut terhapus tidak muncul sendiri di trash dan tidak bisa di-restore sendiri
+		if trash, _ := taskService.GetTrash(1, 1, 10, false); *trash.Total != 1 {
+			t.Errorf("Expected only parent in trash, got %d", *trash.Total)
+		}
+		if _, err := taskService.RestoreTask(child.ID, 1, false); err == nil || err.Error() != model.ErrParentTaskTrashed {
+			t.Errorf("Expected error %s, got %v", model.ErrParentTaskTrashed, err)
+		}
+		if _, err := taskService.RestoreTask(parent.ID, 2, false); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected error %s, got %v", model.ErrForbidden, err)
+		}
+
+		// Restore dibatalkan jika activity gagal dicatat
+		f.activityRepo.err = errors.New("activity insert failed")
+		if _, err := taskService.RestoreTask(parent.ID, 1, false); err == nil {
+			t.Fatal("Expected error when activity cannot be recorded")
+		}
+		if f.taskRepo.tasks[parent.ID].DeletedAt == nil || f.taskRepo.tasks[child.ID].DeletedAt == nil {
+			t.Errorf("Expected restore to be rolled back")
+		}
+		f.activityRepo.err = nil
+
+		restored, err := taskService.RestoreTask(parent.ID, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if restored.DeletedAt != nil {
+			t.Errorf("Expected restored task to leave trash")
+		}
+		if subtasks, _ := taskService.GetSubtasks(parent.ID, 1, false); len(subtasks) != 1 || subtasks[0].ID != child.ID {
+			t.Errorf("Expected subtask to be restored, got %+v", subtasks)
+		}
+
+		latest := f.activityRepo.activities[len(f.activityRepo.activities)-1]
+		if latest.Action != model.ActivityRestored {
+			t.Errorf("Expected %s activity, got %s", model.ActivityRestored, latest.Action)
+		}
+
+		if _, err := taskService.RestoreTask(parent.ID, 1, false); err == nil || err.Error() != model.ErrTaskNotFound {
+			t.Errorf("Expected error %s, got %v", model.ErrTaskNotFound, err)
+		}
+	})
+
+	t.Run("PurgeOnlyRemovesExpiredTasks", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		old, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Old"})
+		recent, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Recent"})
+		taskService.DeleteTask(old.ID, 1, false, model.SubtaskDeleteReparent)
+		taskService.DeleteTask(recent.ID, 1, false, model.SubtaskDeleteReparent)
+
+		longAgo := time.Now().AddDate(0, 0, -40)
+		f.taskRepo.tasks[old.ID].DeletedAt = &longAgo
+
+		purged, err := taskService.PurgeTrash(time.Now().AddDate(0, 0, -30))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if purged != 1 {
+			t.Errorf("Expected 1 purged task, got %d", purged)
+		}
+		if _, exists := f.taskRepo.tasks[old.ID]; exists {
+			t.Errorf("Expected expired task to be purged")
+		}
+		if deleted, _ := f.taskRepo.GetDeletedByID(recent.ID); deleted == nil {
+			t.Errorf("Expected recent task to stay in trash")
+		}
+	})
+}
+
+func TestBulkUpdateTasks(t *testing.T) {
+	newTasks := func(t *testing.T) (*taskFixture, service.TaskService, []int) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		assigneeID := 2
+		own, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Own"})
+		assigned, _ := taskService.CreateTask(3, &model.TaskCreateRequest{Title: "Assigned", AssigneeID: &assigneeID})
+		other, _ := taskService.CreateTask(3, &model.TaskCreateRequest{Title: "Other"})
+		return f, taskService, []int{own.ID, assigned.ID, other.ID, 999}
+	}
+
+	t.Run("UpdateStatusReportsPerItem", func(t *testing.T) {
+		f, taskService, ids := newTasks(t)
+
+		// Task pertama milik user 2, task kedua di-assign ke user 2
+		status := model.TaskStatusCompleted
+		f.taskRepo.tasks[ids[0]].UserID = 2
+		resp, err := taskService.BulkUpdateTasks(2, &model.TaskBulkRequest{TaskIDs: ids, Operation: model.BulkUpdateStatus, Status: &status}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []model.BulkResultStatus{model.BulkResultOK, model.BulkResultOK, model.BulkResultForbidden, model.BulkResultNotFound}
+		for i, result := range resp.Results {
+			if result.TaskID != ids[i] || result.Status != expected[i] {
+				t.Errorf("Expected task %d to be %s, got %+v", ids[i], expected[i], result)
+			}
+		}
+		if resp.Succeeded != 2 || resp.Failed != 2 {
+			t.Errorf("Expected 2 succeeded and 2 failed, got %d and %d", resp.Succeeded, resp.Failed)
+		}
+		if f.taskRepo.tasks[ids[1]].Status != status || f.taskRepo.tasks[ids[2]].Status == status {
+			t.Errorf("Expected only allowed tasks to be updated")
+		}
+		if fmt.Sprint(f.taskRepo.locked) != fmt.Sprint(ids) {
+			t.Errorf("Expected tasks %v to be locked, got %v", ids, f.taskRepo.locked)
+		}
+	})
+
+	t.Run("RollsBackWhenActivityFails", func(t *testing.T) {
+		f, taskService, ids := newTasks(t)
+
+		status := model.TaskStatusCompleted
+		f.activityRepo.err = errors.New("activity insert failed")
+		if _, err := taskService.BulkUpdateTasks(1, &model.TaskBulkRequest{TaskIDs: ids[:1], Operation: model.BulkUpdateStatus, Status: &status}, false); err == nil {
+			t.Fatal("Expected error when activity cannot be recorded")
+		}
+		if f.taskRepo.tasks[ids[0]].Status == status {
+			t.Errorf("Expected status change to be rolled back")
+		}
+	})
+
+	t.Run("AssigneeCannotDelete", func(t *testing.T) {
+		_, taskService, ids := newTasks(t)
+
+		resp, err := taskService.BulkUpdateTasks(2, &model.TaskBulkRequest{TaskIDs: ids[1:2], Operation: model.BulkDelete}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Results[0].Status != model.BulkResultForbidden {
+			t.Errorf("Expected forbidden, got %s", resp.Results[0].Status)
+		}
+	})
+
+	t.Run("DeleteMovesToTrash", func(t *testing.T) {
+		_, taskService, ids := newTasks(t)
+
+		resp, err := taskService.BulkUpdateTasks(3, &model.TaskBulkRequest{TaskIDs: ids[1:3], Operation: model.BulkDelete}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Succeeded != 2 {
+			t.Errorf("Expected 2 deleted tasks, got %d", resp.Succeeded)
+		}
+		if trash, _ := taskService.GetTrash(3, 1, 10, false); *trash.Total != 2 {
+			t.Errorf("Expected 2 tasks in trash, got %d", *trash.Total)
+		}
+	})
+
+	t.Run("MoveProjectChecksOwnership", func(t *testing.T) {
+		f, taskService, ids := newTasks(t)
+		project := &model.Project{UserID: 1, Name: "Sprint"}
+		f.projectRepo.Create(project)
+
+		resp, err := taskService.BulkUpdateTasks(1, &model.TaskBulkRequest{TaskIDs: ids[:3], Operation: model.BulkMoveProject, ProjectID: &project.ID}, true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// Admin boleh mengubah semua task, tetapi project harus milik pemilik task
+		if resp.Results[0].Status != model.BulkResultOK || resp.Results[1].Status != model.BulkResultInvalid {
+			t.Errorf("Expected ok then invalid, got %+v", resp.Results)
+		}
+		if *f.taskRepo.tasks[ids[0]].ProjectID != project.ID {
+			t.Errorf("Expected task to move to project %d", project.ID)
+		}
+	})
+
+	t.Run("AddLabelRecordsActivity", func(t *testing.T) {
+		f, taskService, ids := newTasks(t)
+		label := &model.Label{UserID: 1, Name: "sprint-1", Color: "#00ff00"}
+		f.labelRepo.Create(label)
+
+		resp, err := taskService.BulkUpdateTasks(1, &model.TaskBulkRequest{TaskIDs: []int{ids[0], ids[0]}, Operation: model.BulkAddLabel, LabelID: &label.ID}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.Results) != 1 {
+			t.Errorf("Expected duplicate IDs to be applied once, got %d results", len(resp.Results))
+		}
+		if labels := f.taskRepo.tasks[ids[0]].Labels; len(labels) != 1 || labels[0].ID != label.ID {
+			t.Errorf("Expected label %d, got %v", label.ID, labels)
+		}
+
+		latest := f.activityRepo.activities[len(f.activityRepo.activities)-1]
+		if latest.Field == nil || *latest.Field != "labels" {
+			t.Errorf("Expected labels activity, got %+v", latest)
+		}
+	})
+}
+
+func TestTaskVersioning(t *testing.T) {
+	taskService := newTaskFixture().service()
+	task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+	if task.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", task.Version)
+	}
+
+	title := "Renamed"
+	updated, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &title, IfMatch: []int{1}}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("Expected version 2, got %d", updated.Version)
+	}
+
+	// Request kedua dengan version lama ditolak
+	stale := "Overwritten"
+	_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &stale, IfMatch: []int{1}}, false)
+	if err == nil || err.Error() != model.ErrTaskModified {
+		t.Errorf("Expected error %s, got %v", model.ErrTaskModified, err)
+	}
+	if current, _ := taskService.GetTaskByID(task.ID, 1, false); current.Title != title {
+		t.Errorf("Expected title %q to be kept, got %q", title, current.Title)
+	}
+
+	// If-Match tanpa version yang valid tidak pernah cocok
+	_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &stale, IfMatch: []int{}}, false)
+	if err == nil || err.Error() != model.ErrTaskModified {
+		t.Errorf("Expected error %s, got %v", model.ErrTaskModified, err)
+	}
+
+	// Tanpa If-Match update selalu memakai version terbaru
+	if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &stale}, false); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	t.Run("ClearsNullableFields", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		description := "Notes"
+		dueAt := time.Now().Add(time.Hour)
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", Description: &description, DueAt: &dueAt})
+
+		patch := model.NewTaskPatch(task)
+		patch.Description = nil
+		patch.DueAt = nil
+		updated, err := taskService.PatchTask(task.ID, 1, patch, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.Description != nil || updated.DueAt != nil {
+			t.Errorf("Expected description and due_at to be cleared, got %v and %v", updated.Description, updated.DueAt)
+		}
+	})
+
+	t.Run("RejectsStaleVersion", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+
+		patch := model.NewTaskPatch(task)
+		title := "Changed meanwhile"
+		taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Title: &title}, false)
+
+		_, err := taskService.PatchTask(task.ID, 1, patch, false)
+		if err == nil || err.Error() != model.ErrTaskModified {
+			t.Errorf("Expected error %s, got %v", model.ErrTaskModified, err)
+		}
+	})
+
+	t.Run("AssigneeCanOnlyPatchStatus", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+		assigneeID := 2
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+
+		patch := model.NewTaskPatch(task)
+		patch.Status = model.TaskStatusInProgress
+		updated, err := taskService.PatchTask(task.ID, 2, patch, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		patch = model.NewTaskPatch(updated)
+		patch.Title = "Renamed"
+		_, err = taskService.PatchTask(task.ID, 2, patch, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected error %s, got %v", model.ErrForbidden, err)
+		}
+	})
+}
+
+func TestTaskCursorPagination(t *testing.T) {
+	f := newTaskFixture()
+	taskService := f.service()
+
+	// Dua task berbagi created_at untuk menguji tie-break dengan id
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i, offset := range []int{0, 1, 1, 2, 3} {
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: fmt.Sprintf("Task %d", i+1)})
+		f.taskRepo.tasks[task.ID].CreatedAt = base.Add(time.Duration(offset) * time.Minute)
+	}
+
+	t.Run("CursorRoundTrip", func(t *testing.T) {
+		cursor := model.TaskCursor{CreatedAt: base, ID: 7, Backward: true}
+		decoded, err := model.DecodeTaskCursor(cursor.Encode())
+		if err != nil || *decoded != cursor {
+			t.Fatalf("Expected %+v, got %+v (%v)", cursor, decoded, err)
+		}
+		if _, err := model.DecodeTaskCursor("not-a-cursor"); err == nil {
+			t.Error("Expected error for invalid cursor")
+		}
+	})
+
+	t.Run("WalksForwardAndBack", func(t *testing.T) {
+		page := model.TaskPage{Limit: 2, Cursor: &model.TaskCursor{CreatedAt: base.Add(time.Hour), ID: 1 << 30}}
+		var seen []int
+		var resp *model.TasksResponse
+		for {
+			var err error
+			resp, err = taskService.GetUserTasks(1, page, model.TaskFilter{})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, task := range resp.Tasks {
+				seen = append(seen, task.ID)
+			}
+			if resp.NextCursor == "" {
+				break
+			}
+			page.Cursor, _ = model.DecodeTaskCursor(resp.NextCursor)
+		}
+
+		if want := []int{5, 4, 3, 2, 1}; fmt.Sprint(seen) != fmt.Sprint(want) {
+			t.Fatalf("Expected order %v, got %v", want, seen)
+		}
+		if resp.Total != nil || resp.Page != 0 {
+			t.Errorf("Expected no total or page in cursor mode, got %v and %d", resp.Total, resp.Page)
+		}
+
+		page.Cursor, _ = model.DecodeTaskCursor(resp.PrevCursor)
+		prev, _ := taskService.GetUserTasks(1, page, model.TaskFilter{})
+		if len(prev.Tasks) != 2 || prev.Tasks[0].ID != 3 || prev.Tasks[1].ID != 2 {
+			t.Errorf("Expected previous page [3 2], got %+v", prev.Tasks)
+		}
+	})
+
+	t.Run("PageModeKeepsTotal", func(t *testing.T) {
+		resp, _ := taskService.GetUserTasks(1, model.TaskPage{Page: 1, Limit: 2, IncludeTotal: true}, model.TaskFilter{})
+		if resp.Total == nil || *resp.Total != 5 || resp.Page != 1 || resp.PrevCursor != "" || resp.NextCursor == "" {
+			t.Errorf("Unexpected first page response: %+v", resp)
+		}
+
+		resp, _ = taskService.GetUserTasks(1, model.TaskPage{Page: 1, Limit: 2}, model.TaskFilter{Sort: model.TaskSortPriority})
+		if resp.NextCursor != "" {
+			t.Errorf("Expected no cursor for priority sort, got %s", resp.NextCursor)
+		}
+	})
+}
+
+func TestSearchTasksHighlights(t *testing.T) {
+	taskService := newTaskFixture().service()
+	description := "Roll out the new deployment pipeline"
+	taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Deploy app", Description: &description})
+	taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Unrelated"})
+
+	resp, err := taskService.GetUserTasks(1, model.TaskPage{Page: 1, Limit: 10}, model.TaskFilter{Search: "deploy", Sort: model.TaskSortRelevance})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, task := range resp.Tasks {
+		switch task.Title {
+		case "Deploy app":
+			if task.Highlights == nil || task.Highlights.Title != "<mark>Deploy</mark> app" ||
+				!strings.Contains(task.Highlights.Description, "<mark>deployment</mark>") {
+				t.Errorf("Unexpected highlights %+v", task.Highlights)
+			}
+		default:
+			if task.Highlights != nil {
+				t.Errorf("Expected no highlights for %q, got %+v", task.Title, task.Highlights)
+			}
+		}
+	}
+	if resp.NextCursor != "" || resp.PrevCursor != "" {
+		t.Errorf("Expected no cursors for relevance sort")
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	newTasks := func(t *testing.T, n int) (service.TaskService, []*model.Task) {
+		taskService := newTaskFixture().service()
+		var tasks []*model.Task
+		for i := 0; i < n; i++ {
+			task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: fmt.Sprintf("Task %d", i+1)})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tasks = append(tasks, task)
+		}
+		return taskService, tasks
+	}
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		taskService, tasks := newTasks(t, 2)
+
+		task, err := taskService.AddDependency(tasks[1].ID, tasks[0].ID, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(task.BlockedBy) != 1 || task.BlockedBy[0] != tasks[0].ID {
+			t.Errorf("Expected task to be blocked by %d, got %v", tasks[0].ID, task.BlockedBy)
+		}
+
+		if err := taskService.RemoveDependency(tasks[1].ID, tasks[0].ID, 1, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		err = taskService.RemoveDependency(tasks[1].ID, tasks[0].ID, 1, false)
+		if err == nil || err.Error() != model.ErrDependencyNotFound {
+			t.Errorf("Expected error %s, got %v", model.ErrDependencyNotFound, err)
+		}
+	})
+
+	t.Run("LocksBothTasksInTransaction", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+		blocker, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Blocker"})
+
+		count := f.transactor.count
+		if _, err := taskService.AddDependency(task.ID, blocker.ID, 1, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if f.transactor.count != count+1 {
+			t.Errorf("Expected dependency to be added in one transaction, got %d", f.transactor.count-count)
+		}
+		if fmt.Sprint(f.taskRepo.locked) != fmt.Sprint([]int{task.ID, blocker.ID}) {
+			t.Errorf("Expected tasks %d and %d to be locked, got %v", task.ID, blocker.ID, f.taskRepo.locked)
+		}
+	})
+
+	t.Run("RejectsSelfAndCycles", func(t *testing.T) {
+		taskService, tasks := newTasks(t, 3)
+
+		if _, err := taskService.AddDependency(tasks[0].ID, tasks[0].ID, 1, false); err == nil || err.Error() != model.ErrDependencySelf {
+			t.Errorf("Expected error %s, got %v", model.ErrDependencySelf, err)
+		}
+
+		// 3 diblokir 2, 2 diblokir 1: 1 tidak boleh diblokir 3
+		taskService.AddDependency(tasks[2].ID, tasks[1].ID, 1, false)
+		taskService.AddDependency(tasks[1].ID, tasks[0].ID, 1, false)
+		if _, err := taskService.AddDependency(tasks[0].ID, tasks[2].ID, 1, false); err == nil || err.Error() != model.ErrDependencyCycle {
+			t.Errorf("Expected error %s, got %v", model.ErrDependencyCycle, err)
+		}
+	})
+
+	t.Run("BlockerMustBeVisible", func(t *testing.T) {
+		taskService, tasks := newTasks(t, 1)
+		other, _ := taskService.CreateTask(2, &model.TaskCreateRequest{Title: "Private"})
+
+		if _, err := taskService.AddDependency(tasks[0].ID, other.ID, 1, false); err == nil || err.Error() != model.ErrBlockerNotFound {
+			t.Errorf("Expected error %s, got %v", model.ErrBlockerNotFound, err)
+		}
+	})
+
+	t.Run("IncompleteBlockersPreventCompletion", func(t *testing.T) {
+		taskService, tasks := newTasks(t, 2)
+		taskService.AddDependency(tasks[1].ID, tasks[0].ID, 1, false)
+
+		completed := model.TaskStatusCompleted
+		_, err := taskService.UpdateTask(tasks[1].ID, 1, &model.TaskUpdateRequest{Status: &completed}, false)
+		if err == nil || err.Error() != model.ErrTaskBlocked {
+			t.Fatalf("Expected error %s, got %v", model.ErrTaskBlocked, err)
+		}
+
+		resp, _ := taskService.BulkUpdateTasks(1, &model.TaskBulkRequest{TaskIDs: []int{tasks[1].ID}, Operation: model.BulkUpdateStatus, Status: &completed}, false)
+		if resp.Failed != 1 || resp.Results[0].Status != model.BulkResultInvalid {
+			t.Errorf("Expected bulk completion to be rejected, got %+v", resp.Results)
+		}
+
+		// Admin boleh menyelesaikan task yang masih terblokir
+		if _, err := taskService.UpdateTask(tasks[1].ID, 1, &model.TaskUpdateRequest{Status: &completed}, true); err != nil {
+			t.Errorf("Expected admin override, got %v", err)
+		}
+
+		taskService.UpdateTask(tasks[0].ID, 1, &model.TaskUpdateRequest{Status: &completed}, false)
+		reopened := model.TaskStatusPending
+		taskService.UpdateTask(tasks[1].ID, 1, &model.TaskUpdateRequest{Status: &reopened}, false)
+		if _, err := taskService.UpdateTask(tasks[1].ID, 1, &model.TaskUpdateRequest{Status: &completed}, false); err != nil {
+			t.Errorf("Expected no error once blockers are completed, got %v", err)
+		}
+	})
+}
+
+func TestTaskBoard(t *testing.T) {
+	newBoard := func(t *testing.T, n int) (service.TaskService, []*model.Task) {
+		taskService := newTaskFixture().service()
+		var tasks []*model.Task
+		for i := 0; i < n; i++ {
+			task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: fmt.Sprintf("Task %d", i+1)})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tasks = append(tasks, task)
+		}
+		return taskService, tasks
+	}
+
+	columnIDs := func(t *testing.T, taskService service.TaskService, status model.TaskStatus) []int {
+		board, err := taskService.GetBoard(1, model.TaskFilter{}, model.DefaultBoardLimit, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, column := range board.Columns {
+			if column.Status == status {
+				ids := []int{}
+				for _, task := range column.Tasks {
+					ids = append(ids, task.ID)
+				}
+				return ids
+			}
+		}
+		t.Fatalf("Expected column %s", status)
+		return nil
+	}
+
+	t.Run("NewTasksAppendToColumn", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 3)
+
+		board, err := taskService.GetBoard(1, model.TaskFilter{}, model.DefaultBoardLimit, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(board.Columns) != len(model.DefaultWorkflowStatuses) {
+			t.Fatalf("Expected a column per workflow status, got %d", len(board.Columns))
+		}
+		expected := []int{tasks[0].ID, tasks[1].ID, tasks[2].ID}
+		if ids := columnIDs(t, taskService, model.TaskStatusPending); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			t.Errorf("Expected order %v, got %v", expected, ids)
+		}
+	})
+
+	t.Run("MoveWithinAndAcrossColumns", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 4)
+
+		// Task 4 dipindah ke antara task 1 dan task 2
+		if _, err := taskService.MoveTask(tasks[3].ID, 1, &model.TaskMoveRequest{BeforeID: &tasks[0].ID, AfterID: &tasks[1].ID}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []int{tasks[0].ID, tasks[3].ID, tasks[1].ID, tasks[2].ID}
+		if ids := columnIDs(t, taskService, model.TaskStatusPending); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			t.Errorf("Expected order %v, got %v", expected, ids)
+		}
+
+		moved, err := taskService.MoveTask(tasks[1].ID, 1, &model.TaskMoveRequest{Status: model.TaskStatusInProgress}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if moved.Status != model.TaskStatusInProgress || moved.Version != tasks[1].Version+1 {
+			t.Errorf("Expected moved task in progress with new version, got %s v%d", moved.Status, moved.Version)
+		}
+
+		// Task 3 ditaruh di atas task 2 pada kolom in_progress
+		if _, err := taskService.MoveTask(tasks[2].ID, 1, &model.TaskMoveRequest{Status: model.TaskStatusInProgress, AfterID: &tasks[1].ID}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected = []int{tasks[2].ID, tasks[1].ID}
+		if ids := columnIDs(t, taskService, model.TaskStatusInProgress); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			t.Errorf("Expected order %v, got %v", expected, ids)
+		}
+	})
+
+	t.Run("StaleNeighboursConflict", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 3)
+
+		// Urutan before/after terbalik atau tetangga di kolom lain berarti board client sudah usang
+		_, err := taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{BeforeID: &tasks[2].ID, AfterID: &tasks[1].ID}, false)
+		if err == nil || err.Error() != model.ErrBoardConflict {
+			t.Errorf("Expected error %s, got %v", model.ErrBoardConflict, err)
+		}
+		_, err = taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{Status: model.TaskStatusInProgress, BeforeID: &tasks[1].ID}, false)
+		if err == nil || err.Error() != model.ErrBoardConflict {
+			t.Errorf("Expected error %s, got %v", model.ErrBoardConflict, err)
+		}
+
+		_, err = taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{BeforeID: &tasks[0].ID}, false)
+		if err == nil || err.Error() != model.ErrInvalidMove {
+			t.Errorf("Expected error %s, got %v", model.ErrInvalidMove, err)
+		}
+	})
+
+	t.Run("AssignedTasksKeepOwnerRanks", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 2)
+		assigneeID := 1
+		assigned, err := taskService.CreateTask(2, &model.TaskCreateRequest{Title: "Assigned", AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Task milik user 2 dipindah ke antara task milik user 1
+		if _, err := taskService.MoveTask(assigned.ID, 1, &model.TaskMoveRequest{BeforeID: &tasks[0].ID, AfterID: &tasks[1].ID}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		// Task milik user 1 tanpa tetangga ditaruh di akhir kolom
+		if _, err := taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{assigned.ID, tasks[1].ID, tasks[0].ID}
+		if ids := columnIDs(t, taskService, model.TaskStatusPending); fmt.Sprint(ids) != fmt.Sprint(expected) {
+			t.Errorf("Expected order %v, got %v", expected, ids)
+		}
+	})
+
+	t.Run("NeighbourMustBeVisible", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 1)
+		other, _ := taskService.CreateTask(2, &model.TaskCreateRequest{Title: "Private"})
+
+		_, err := taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{AfterID: &other.ID}, false)
+		if err == nil || err.Error() != model.ErrMoveNeighbourNotFound {
+			t.Errorf("Expected error %s, got %v", model.ErrMoveNeighbourNotFound, err)
+		}
+
+		_, err = taskService.MoveTask(other.ID, 1, &model.TaskMoveRequest{}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected error %s, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("MoveFollowsStatusRules", func(t *testing.T) {
+		taskService, tasks := newBoard(t, 2)
+		taskService.AddDependency(tasks[1].ID, tasks[0].ID, 1, false)
+
+		_, err := taskService.MoveTask(tasks[1].ID, 1, &model.TaskMoveRequest{Status: model.TaskStatusCompleted}, false)
+		if err == nil || err.Error() != model.ErrTaskBlocked {
+			t.Errorf("Expected error %s, got %v", model.ErrTaskBlocked, err)
+		}
+
+		_, err = taskService.MoveTask(tasks[0].ID, 1, &model.TaskMoveRequest{Status: "archived"}, false)
+		if err == nil || err.Error() != model.ErrInvalidStatus {
+			t.Errorf("Expected error %s, got %v", model.ErrInvalidStatus, err)
+		}
+	})
+}
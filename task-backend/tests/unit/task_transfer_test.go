@@ -0,0 +1,218 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/internal/handler"
+	"github.com/Mahathirrr/task-management-backend/internal/middleware"
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/pkg/jwt"
+)
+
+func TestTaskExport(t *testing.T) {
+	f := newTaskFixture()
+	taskService := f.service()
+
+	// Lebih dari satu batch export
+	for i := 0; i < 1203; i++ {
+		if _, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if _, err := taskService.CreateTask(2, &model.TaskCreateRequest{Title: "Other"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var ids []int
+	err := taskService.ExportTasks(1, model.TaskFilter{Sort: model.TaskSortPriority}, false, func(task *model.Task) error {
+		ids = append(ids, task.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(ids) != 1203 {
+		t.Fatalf("Expected 1203 tasks, got %d", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("Expected tasks oldest first without duplicates, got %d after %d", ids[i], ids[i-1])
+		}
+	}
+
+	var total int
+	taskService.ExportTasks(1, model.TaskFilter{}, true, func(task *model.Task) error {
+		total++
+		return nil
+	})
+	if total != 1204 {
+		t.Errorf("Expected admin to export 1204 tasks, got %d", total)
+	}
+}
+
+func TestTaskImport(t *testing.T) {
+	parentID := 10
+	missingParentID := 99
+	rows := func() []model.TaskImportRow {
+		return []model.TaskImportRow{
+			{Row: 1, Record: model.TaskRecord{ID: 10, Title: "Parent", Priority: model.TaskPriorityHigh}},
+			{Row: 2, Record: model.TaskRecord{ID: 11, ParentTaskID: &parentID, Title: "Child"}},
+			{Row: 3, Record: model.TaskRecord{Title: "Standalone", Status: model.TaskStatusCompleted}},
+		}
+	}
+
+	t.Run("CreatesTasks", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		resp, err := taskService.ImportTasks(1, rows(), false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Created != 3 || resp.Valid != 3 || len(resp.Errors) != 0 || len(resp.TaskIDs) != 3 {
+			t.Fatalf("Expected 3 created tasks, got %+v", resp)
+		}
+
+		child := f.taskRepo.tasks[resp.TaskIDs[1]]
+		if child.ParentTaskID == nil || *child.ParentTaskID != resp.TaskIDs[0] {
+			t.Errorf("Expected child of task %d, got %v", resp.TaskIDs[0], child.ParentTaskID)
+		}
+		if f.taskRepo.tasks[resp.TaskIDs[0]].Priority != model.TaskPriorityHigh || f.taskRepo.tasks[resp.TaskIDs[2]].Status != model.TaskStatusCompleted {
+			t.Error("Expected imported priority and status")
+		}
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		resp, err := taskService.ImportTasks(1, rows(), true)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.Created != 0 || resp.Valid != 3 || !resp.DryRun {
+			t.Errorf("Expected 3 valid rows and nothing created, got %+v", resp)
+		}
+		if len(f.taskRepo.tasks) != 0 || f.transactor.count != 0 {
+			t.Errorf("Expected no tasks and no transaction, got %d tasks, %d transactions", len(f.taskRepo.tasks), f.transactor.count)
+		}
+	})
+
+	t.Run("DepthCheckedBeforeWriting", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		ids := []int{1, 2, 3}
+		deep := []model.TaskImportRow{
+			{Row: 1, Record: model.TaskRecord{ID: 1, Title: "Level 1"}},
+			{Row: 2, Record: model.TaskRecord{ID: 2, ParentTaskID: &ids[0], Title: "Level 2"}},
+			{Row: 3, Record: model.TaskRecord{ID: 3, ParentTaskID: &ids[1], Title: "Level 3"}},
+			{Row: 4, Record: model.TaskRecord{ID: 4, ParentTaskID: &ids[2], Title: "Level 4"}},
+		}
+
+		resp, err := taskService.ImportTasks(1, deep, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.Errors) != 1 || resp.Errors[0].Row != 4 || resp.Errors[0].Errors[0].Field != "parent_task_id" {
+			t.Errorf("Expected depth error on row 4, got %+v", resp.Errors)
+		}
+		if f.transactor.count != 0 {
+			t.Errorf("Expected no transaction for invalid import, got %d", f.transactor.count)
+		}
+	})
+
+	t.Run("RowErrorsRollBack", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		other := &model.Label{UserID: 2, Name: "bug", Color: "#ff0000"}
+		f.labelRepo.Create(other)
+
+		invalid := append(rows(),
+			model.TaskImportRow{Row: 4, Record: model.TaskRecord{Title: "Labelled", LabelIDs: []int{other.ID}}},
+			model.TaskImportRow{Row: 5, Record: model.TaskRecord{ParentTaskID: &missingParentID, Title: "Orphan"}},
+			model.TaskImportRow{Row: 6, Errors: []model.ValidationError{{Field: "due_at", Message: "due_at must be an RFC3339 timestamp"}}},
+			model.TaskImportRow{Row: 7, Record: model.TaskRecord{ID: 10, Title: "Duplicate"}},
+		)
+
+		resp, err := taskService.ImportTasks(1, invalid, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var failed []int
+		for _, rowErr := range resp.Errors {
+			failed = append(failed, rowErr.Row)
+		}
+		if len(failed) != 4 || failed[0] != 4 || failed[1] != 5 || failed[2] != 6 || failed[3] != 7 {
+			t.Errorf("Expected errors on rows 4-7, got %v", failed)
+		}
+		if resp.Errors[0].Errors[0].Field != "label_ids" {
+			t.Errorf("Expected label_ids error, got %+v", resp.Errors[0].Errors)
+		}
+		if resp.Created != 0 || resp.Valid != 3 {
+			t.Errorf("Expected 3 valid rows and nothing created, got %+v", resp)
+		}
+		if len(f.taskRepo.tasks) != 0 || len(f.activityRepo.activities) != 0 {
+			t.Errorf("Expected rollback, got %d tasks", len(f.taskRepo.tasks))
+		}
+	})
+}
+
+func TestTaskCSVRoundTrip(t *testing.T) {
+	descriptions := []string{"- [ ] item", "=SUM(A1:A2)", "+ note", "@mention", "'quoted", "  indented"}
+
+	source := newTaskFixture()
+	for _, description := range descriptions {
+		description := description
+		if _, err := source.service().CreateTask(1, &model.TaskCreateRequest{Title: "=" + description, Description: &description}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Request dengan user 1 di context, seperti setelah AuthMiddleware
+	request := func(method, target, body string) *http.Request {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		return r.WithContext(context.WithValue(r.Context(), middleware.UserContextKey, &jwt.Claims{UserID: 1, Role: string(model.UserRoleUser)}))
+	}
+
+	exported := httptest.NewRecorder()
+	handler.NewTaskHandler(source.service()).ExportTasks(exported, request(http.MethodGet, "/api/v1/tasks/export?format=csv", ""))
+	if exported.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", exported.Code, exported.Body.String())
+	}
+	if strings.Contains(exported.Body.String(), ",=SUM") {
+		t.Errorf("Expected formula cells to be escaped, got %s", exported.Body.String())
+	}
+
+	target := newTaskFixture()
+	imported := httptest.NewRecorder()
+	handler.NewTaskHandler(target.service()).ImportTasks(imported, request(http.MethodPost, "/api/v1/tasks/import?format=csv", exported.Body.String()))
+	if imported.Code != http.StatusOK && imported.Code != http.StatusCreated {
+		t.Fatalf("Expected import to succeed, got %d: %s", imported.Code, imported.Body.String())
+	}
+
+	got := make(map[string]string)
+	for _, task := range target.taskRepo.tasks {
+		if task.Description == nil {
+			t.Fatalf("Expected description on imported task %d", task.ID)
+		}
+		got[*task.Description] = task.Title
+	}
+	for _, description := range descriptions {
+		title, ok := got[description]
+		if !ok {
+			t.Errorf("Expected description %q after round trip, got %v", description, got)
+			continue
+		}
+		if title != "="+description {
+			t.Errorf("Expected title %q, got %q", "="+description, title)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package unit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+)
+
+// Mock TemplateRepository for testing
+type mockTemplateRepository struct {
+	templates map[int]*model.TaskTemplate
+	nextID    int
+}
+
+func newMockTemplateRepository() *mockTemplateRepository {
+	return &mockTemplateRepository{
+		templates: make(map[int]*model.TaskTemplate),
+		nextID:    1,
+	}
+}
+
+func (m *mockTemplateRepository) Create(template *model.TaskTemplate) error {
+	template.ID = m.nextID
+	m.nextID++
+	copied := *template
+	m.templates[template.ID] = &copied
+	return nil
+}
+
+func (m *mockTemplateRepository) GetByID(id int) (*model.TaskTemplate, error) {
+	template, exists := m.templates[id]
+	if !exists {
+		return nil, nil
+	}
+	copied := *template
+	return &copied, nil
+}
+
+func (m *mockTemplateRepository) GetByUserID(userID int) ([]model.TaskTemplate, error) {
+	var templates []model.TaskTemplate
+	for id := 1; id < m.nextID; id++ {
+		if template, exists := m.templates[id]; exists && template.UserID == userID {
+			templates = append(templates, *template)
+		}
+	}
+	return templates, nil
+}
+
+func (m *mockTemplateRepository) Update(template *model.TaskTemplate) error {
+	copied := *template
+	m.templates[template.ID] = &copied
+	return nil
+}
+
+func (m *mockTemplateRepository) Delete(id int) error {
+	delete(m.templates, id)
+	return nil
+}
+
+func TestTemplates(t *testing.T) {
+	newTemplateService := func() (service.TemplateService, *taskFixture) {
+		f := newTaskFixture()
+		return service.NewTemplateService(newMockTemplateRepository(), f.labelRepo, f.userRepo, f.service()), f
+	}
+
+	description := "Prepared by {{name}} on {{ date }}"
+	subtaskDescription := "Owner: {{name}}"
+	req := &model.TaskTemplateRequest{
+		Name:        "Release",
+		Title:       "Release {{version}}",
+		Description: &description,
+		Priority:    model.TaskPriorityHigh,
+		Subtasks: []model.TemplateSubtask{
+			{Title: "Tag {{version}}"},
+			{Title: "Write changelog", Description: &subtaskDescription},
+		},
+	}
+
+	t.Run("InstantiateCreatesTasks", func(t *testing.T) {
+		templateService, f := newTemplateService()
+
+		template, err := templateService.CreateTemplate(1, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		resp, err := templateService.InstantiateTemplate(template.ID, 1, &model.TemplateInstantiateRequest{
+			Variables: map[string]string{"version": "v1.2.0"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		today := time.Now().Format("2006-01-02")
+		if resp.Task.Title != "Release v1.2.0" || *resp.Task.Description != "Prepared by Owner on "+today {
+			t.Errorf("Expected placeholders to be filled, got %q, %q", resp.Task.Title, *resp.Task.Description)
+		}
+		if resp.Task.Priority != model.TaskPriorityHigh || resp.Task.UserID != 1 {
+			t.Errorf("Expected high priority task owned by user 1, got %s owned by %d", resp.Task.Priority, resp.Task.UserID)
+		}
+		if len(resp.Subtasks) != 2 || resp.Subtasks[0].Title != "Tag v1.2.0" || *resp.Subtasks[1].Description != "Owner: Owner" {
+			t.Fatalf("Expected 2 rendered subtasks, got %+v", resp.Subtasks)
+		}
+		for _, subtask := range resp.Subtasks {
+			if subtask.ParentTaskID == nil || *subtask.ParentTaskID != resp.Task.ID {
+				t.Errorf("Expected subtask of task %d, got %v", resp.Task.ID, subtask.ParentTaskID)
+			}
+		}
+		if len(f.taskRepo.tasks) != 3 {
+			t.Errorf("Expected 3 tasks, got %d", len(f.taskRepo.tasks))
+		}
+	})
+
+	t.Run("MissingVariable", func(t *testing.T) {
+		templateService, f := newTemplateService()
+
+		template, err := templateService.CreateTemplate(1, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		_, err = templateService.InstantiateTemplate(template.ID, 1, &model.TemplateInstantiateRequest{})
+		if err == nil || err.Error() != model.ErrTemplateVariableMissing {
+			t.Errorf("Expected %q error, got %v", model.ErrTemplateVariableMissing, err)
+		}
+		if len(f.taskRepo.tasks) != 0 {
+			t.Errorf("Expected no tasks, got %d", len(f.taskRepo.tasks))
+		}
+
+		// Variables boleh menimpa placeholder bawaan
+		resp, err := templateService.InstantiateTemplate(template.ID, 1, &model.TemplateInstantiateRequest{
+			Variables: map[string]string{"version": "v2", "name": "Release team", "date": "2030-01-01"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if *resp.Task.Description != "Prepared by Release team on 2030-01-01" {
+			t.Errorf("Expected overridden placeholders, got %q", *resp.Task.Description)
+		}
+	})
+
+	t.Run("InvalidRenderedTitle", func(t *testing.T) {
+		templateService, f := newTemplateService()
+
+		template, err := templateService.CreateTemplate(1, &model.TaskTemplateRequest{
+			Name:     "Generic",
+			Title:    "{{title}}",
+			Subtasks: []model.TemplateSubtask{{Title: "Check {{title}}"}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		for _, title := range []string{"", strings.Repeat("x", 250)} {
+			_, err := templateService.InstantiateTemplate(template.ID, 1, &model.TemplateInstantiateRequest{
+				Variables: map[string]string{"title": title},
+			})
+			if err == nil || err.Error() != model.ErrTemplateTitleInvalid {
+				t.Errorf("Expected %q error for title of length %d, got %v", model.ErrTemplateTitleInvalid, len(title), err)
+			}
+		}
+		if len(f.taskRepo.tasks) != 0 {
+			t.Errorf("Expected no tasks, got %d", len(f.taskRepo.tasks))
+		}
+	})
+
+	t.Run("PerUser", func(t *testing.T) {
+		templateService, f := newTemplateService()
+
+		other := &model.Label{UserID: 2, Name: "bug", Color: "#ff0000"}
+		f.labelRepo.Create(other)
+		withLabel := *req
+		withLabel.LabelIDs = []int{other.ID}
+		if _, err := templateService.CreateTemplate(1, &withLabel); err == nil || err.Error() != model.ErrInvalidLabels {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidLabels, err)
+		}
+
+		template, err := templateService.CreateTemplate(1, req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, err := templateService.GetTemplateByID(template.ID, 2); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+		if _, err := templateService.InstantiateTemplate(template.ID, 2, &model.TemplateInstantiateRequest{}); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+		if templates, _ := templateService.GetUserTemplates(2); len(templates) != 0 {
+			t.Errorf("Expected no templates for user 2, got %d", len(templates))
+		}
+
+		if err := templateService.DeleteTemplate(template.ID, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := templateService.GetTemplateByID(template.ID, 1); err == nil || err.Error() != model.ErrTemplateNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrTemplateNotFound, err)
+		}
+	})
+
+	t.Run("TransactionRollback", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		failed := errors.New("failed")
+		err := taskService.WithTransaction(func(tx service.TaskService) error {
+			task, err := tx.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+			if err != nil {
+				return err
+			}
+			if _, err := tx.CreateSubtask(task.ID, 1, &model.TaskCreateRequest{Title: "Subtask"}, false); err != nil {
+				return err
+			}
+			return failed
+		})
+		if err != failed {
+			t.Fatalf("Expected %v, got %v", failed, err)
+		}
+		if len(f.taskRepo.tasks) != 0 || len(f.activityRepo.activities) != 0 {
+			t.Errorf("Expected rollback, got %d tasks and %d activities", len(f.taskRepo.tasks), len(f.activityRepo.activities))
+		}
+	})
+}
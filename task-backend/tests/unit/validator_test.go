@@ -51,6 +51,62 @@ func TestValidateTaskStruct(t *testing.T) {
 	})
 }
 
+func TestValidateTaskPriority(t *testing.T) {
+	t.Run("ValidPriority", func(t *testing.T) {
+		req := model.TaskCreateRequest{
+			Title:    "Test Task",
+			Priority: model.TaskPriorityUrgent,
+		}
+
+		errors := validator.ValidateStruct(req)
+		if len(errors) != 0 {
+			t.Errorf("Expected no validation errors, got %d errors", len(errors))
+		}
+	})
+
+	t.Run("InvalidPriority", func(t *testing.T) {
+		priority := model.TaskPriority("critical")
+		req := model.TaskUpdateRequest{
+			Priority: &priority,
+		}
+
+		errors := validator.ValidateStruct(req)
+		if len(errors) != 1 || errors[0].Field != "Priority" {
+			t.Errorf("Expected validation error for field Priority, got %v", errors)
+		}
+	})
+}
+
+func TestValidateLabel(t *testing.T) {
+	t.Run("ValidLabel", func(t *testing.T) {
+		req := model.LabelCreateRequest{Name: "customer-x", Color: "#ff8800"}
+
+		errors := validator.ValidateStruct(req)
+		if len(errors) != 0 {
+			t.Errorf("Expected no validation errors, got %v", errors)
+		}
+	})
+
+	t.Run("InvalidLabel", func(t *testing.T) {
+		req := model.LabelCreateRequest{Name: "a,b", Color: "orange"}
+
+		errors := validator.ValidateStruct(req)
+		if len(errors) != 2 {
+			t.Errorf("Expected 2 validation errors, got %v", errors)
+		}
+	})
+
+	t.Run("InvalidLabelIDs", func(t *testing.T) {
+		labelIDs := []int{1, 0}
+		req := model.TaskUpdateRequest{LabelIDs: &labelIDs}
+
+		errors := validator.ValidateStruct(req)
+		if len(errors) != 1 {
+			t.Errorf("Expected 1 validation error, got %v", errors)
+		}
+	})
+}
+
 func TestValidateStruct(t *testing.T) {
 	t.Run("ValidUserRegisterRequest", func(t *testing.T) {
 		req := model.UserRegisterRequest{
@@ -0,0 +1,247 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+	"github.com/Mahathirrr/task-management-backend/pkg/validator"
+)
+
+// Mock WorkflowRepository for testing, berisi workflow default dengan ID 1
+type mockWorkflowRepository struct {
+	workflows map[int]*model.Workflow
+	nextID    int
+}
+
+func newMockWorkflowRepository() *mockWorkflowRepository {
+	defaultWorkflow := model.NewDefaultWorkflow()
+	defaultWorkflow.ID = 1
+	return &mockWorkflowRepository{
+		workflows: map[int]*model.Workflow{1: defaultWorkflow},
+		nextID:    2,
+	}
+}
+
+func (m *mockWorkflowRepository) Create(workflow *model.Workflow) error {
+	workflow.ID = m.nextID
+	m.nextID++
+	m.workflows[workflow.ID] = workflow
+	return nil
+}
+
+func (m *mockWorkflowRepository) GetByID(id int) (*model.Workflow, error) {
+	return m.workflows[id], nil
+}
+
+func (m *mockWorkflowRepository) GetDefault() (*model.Workflow, error) {
+	for _, workflow := range m.workflows {
+		if workflow.IsDefault {
+			return workflow, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockWorkflowRepository) GetAll() ([]model.Workflow, error) {
+	var workflows []model.Workflow
+	for _, workflow := range m.workflows {
+		workflows = append(workflows, *workflow)
+	}
+	return workflows, nil
+}
+
+func (m *mockWorkflowRepository) Update(workflow *model.Workflow) error {
+	m.workflows[workflow.ID] = workflow
+	return nil
+}
+
+func (m *mockWorkflowRepository) Delete(id int) error {
+	delete(m.workflows, id)
+	return nil
+}
+
+// reviewWorkflow: todo -> review -> done, review boleh kembali ke todo
+func reviewWorkflow() *model.WorkflowRequest {
+	return &model.WorkflowRequest{
+		Name: "Review",
+		Statuses: []model.WorkflowStatus{
+			{Key: "todo", Name: "To Do", IsInitial: true},
+			{Key: "review", Name: "Review"},
+			{Key: "completed", Name: "Done"},
+		},
+		Transitions: []model.WorkflowTransition{
+			{From: "todo", To: "review"},
+			{From: "review", To: "todo"},
+			{From: "review", To: "completed"},
+		},
+	}
+}
+
+func TestWorkflows(t *testing.T) {
+	// Status yang dikenal validator bersifat global, kembalikan ke default setelah test
+	defer model.SetKnownTaskStatuses(model.DefaultWorkflowStatuses)
+
+	t.Run("DefaultWorkflowAllowsAnyTransition", func(t *testing.T) {
+		taskService := newTaskFixture().service()
+
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if task.Status != model.TaskStatusPending {
+			t.Errorf("Expected initial status %s, got %s", model.TaskStatusPending, task.Status)
+		}
+
+		for _, status := range []model.TaskStatus{model.TaskStatusCompleted, model.TaskStatusPending, model.TaskStatusInProgress} {
+			status := status
+			if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &status}, false); err != nil {
+				t.Errorf("Expected transition to %s to be allowed, got %v", status, err)
+			}
+		}
+	})
+
+	t.Run("ProjectWorkflowEnforcesTransitions", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+		workflowService := service.NewWorkflowService(f.workflowRepo)
+
+		workflow, err := workflowService.CreateWorkflow(reviewWorkflow())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		project, err := service.NewProjectService(f.projectRepo, f.workflowRepo).CreateProject(1, &model.ProjectCreateRequest{Name: "Project", WorkflowID: &workflow.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", ProjectID: &project.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if task.Status != "todo" {
+			t.Errorf("Expected initial status todo, got %s", task.Status)
+		}
+
+		completed := model.TaskStatusCompleted
+		_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &completed}, false)
+		if err == nil || err.Error() != model.ErrInvalidTransition {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidTransition, err)
+		}
+
+		inProgress := model.TaskStatusInProgress
+		_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &inProgress}, false)
+		if err == nil || err.Error() != model.ErrInvalidStatus {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidStatus, err)
+		}
+
+		review := model.TaskStatus("review")
+		if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &review}, false); err != nil {
+			t.Fatalf("Expected transition to review, got %v", err)
+		}
+		if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &completed}, false); err != nil {
+			t.Errorf("Expected transition to completed, got %v", err)
+		}
+	})
+
+	t.Run("DoneStatusFollowsWorkflow", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		// Status selesai pada workflow project adalah shipped, bukan completed
+		req := reviewWorkflow()
+		req.Statuses[2] = model.WorkflowStatus{Key: "shipped", Name: "Shipped", IsDone: true}
+		req.Transitions[2].To = "shipped"
+		workflow, err := service.NewWorkflowService(f.workflowRepo).CreateWorkflow(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		project, err := service.NewProjectService(f.projectRepo, f.workflowRepo).CreateProject(1, &model.ProjectCreateRequest{Name: "Project", WorkflowID: &workflow.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		blocker, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Blocker"})
+		review := model.TaskStatus("review")
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", ProjectID: &project.ID, Status: review})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := taskService.AddDependency(task.ID, blocker.ID, 1, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		shipped := model.TaskStatus("shipped")
+		_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{Status: &shipped}, false)
+		if err == nil || err.Error() != model.ErrTaskBlocked {
+			t.Errorf("Expected %q error, got %v", model.ErrTaskBlocked, err)
+		}
+	})
+
+	t.Run("ProjectChangeChecksStatus", func(t *testing.T) {
+		f := newTaskFixture()
+		taskService := f.service()
+
+		workflow, err := service.NewWorkflowService(f.workflowRepo).CreateWorkflow(reviewWorkflow())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		project, err := service.NewProjectService(f.projectRepo, f.workflowRepo).CreateProject(1, &model.ProjectCreateRequest{Name: "Project", WorkflowID: &workflow.ID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// in_progress tidak ada di workflow project tujuan
+		task, _ := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", Status: model.TaskStatusInProgress})
+		_, err = taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{ProjectID: &project.ID}, false)
+		if err == nil || err.Error() != model.ErrInvalidStatus {
+			t.Errorf("Expected %q error, got %v", model.ErrInvalidStatus, err)
+		}
+
+		todo := model.TaskStatus("todo")
+		if _, err := taskService.UpdateTask(task.ID, 1, &model.TaskUpdateRequest{ProjectID: &project.ID, Status: &todo}, false); err != nil {
+			t.Errorf("Expected move with valid status, got %v", err)
+		}
+	})
+
+	t.Run("RejectsInconsistentWorkflow", func(t *testing.T) {
+		workflowService := service.NewWorkflowService(newMockWorkflowRepository())
+
+		req := reviewWorkflow()
+		req.Transitions = append(req.Transitions, model.WorkflowTransition{From: "todo", To: "blocked"})
+		if _, err := workflowService.CreateWorkflow(req); err == nil || err.Error() != model.ErrInvalidWorkflow {
+			t.Errorf("Expected %q error for unknown transition target, got %v", model.ErrInvalidWorkflow, err)
+		}
+
+		req = reviewWorkflow()
+		req.Statuses[1].IsInitial = true
+		if _, err := workflowService.CreateWorkflow(req); err == nil || err.Error() != model.ErrInvalidWorkflow {
+			t.Errorf("Expected %q error for two initial statuses, got %v", model.ErrInvalidWorkflow, err)
+		}
+	})
+
+	t.Run("DefaultWorkflowCannotBeDeleted", func(t *testing.T) {
+		workflowService := service.NewWorkflowService(newMockWorkflowRepository())
+
+		if err := workflowService.DeleteWorkflow(1); err == nil || err.Error() != model.ErrDefaultWorkflow {
+			t.Errorf("Expected %q error, got %v", model.ErrDefaultWorkflow, err)
+		}
+	})
+
+	t.Run("ValidatorAcceptsWorkflowStatuses", func(t *testing.T) {
+		model.SetKnownTaskStatuses(model.DefaultWorkflowStatuses)
+
+		req := model.TaskCreateRequest{Title: "Task", Status: "review"}
+		if errors := validator.ValidateStruct(req); len(errors) == 0 {
+			t.Fatal("Expected unknown status to be rejected before workflow exists")
+		}
+
+		workflowService := service.NewWorkflowService(newMockWorkflowRepository())
+		if _, err := workflowService.CreateWorkflow(reviewWorkflow()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if errors := validator.ValidateStruct(req); len(errors) != 0 {
+			t.Errorf("Expected workflow status to be accepted, got %v", errors)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Mahathirrr/task-management-backend/internal/model"
+	"github.com/Mahathirrr/task-management-backend/internal/repository"
+	"github.com/Mahathirrr/task-management-backend/internal/service"
+)
+
+// Mock WorklogRepository for testing
+type mockWorklogRepository struct {
+	worklogs map[int]*model.Worklog
+	nextID   int
+}
+
+func newMockWorklogRepository() *mockWorklogRepository {
+	return &mockWorklogRepository{
+		worklogs: make(map[int]*model.Worklog),
+		nextID:   1,
+	}
+}
+
+func (m *mockWorklogRepository) Create(worklog *model.Worklog) error {
+	// Meniru unique key uniq_running_timer
+	if worklog.EndedAt == nil {
+		if running, _ := m.GetRunning(worklog.UserID); running != nil {
+			return repository.ErrTimerRunning
+		}
+	}
+	worklog.ID = m.nextID
+	m.nextID++
+	copied := *worklog
+	m.worklogs[worklog.ID] = &copied
+	return nil
+}
+
+func (m *mockWorklogRepository) GetByID(id int) (*model.Worklog, error) {
+	worklog, exists := m.worklogs[id]
+	if !exists {
+		return nil, nil
+	}
+	return m.withDuration(worklog), nil
+}
+
+func (m *mockWorklogRepository) GetRunning(userID int) (*model.Worklog, error) {
+	for _, worklog := range m.worklogs {
+		if worklog.UserID == userID && worklog.Running() {
+			return m.withDuration(worklog), nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockWorklogRepository) Stop(id int, endedAt time.Time) error {
+	if worklog, exists := m.worklogs[id]; exists && worklog.Running() {
+		worklog.EndedAt = &endedAt
+	}
+	return nil
+}
+
+func (m *mockWorklogRepository) GetByTaskID(taskID int, period model.WorklogRange) ([]model.Worklog, error) {
+	var worklogs []model.Worklog
+	for id := m.nextID - 1; id >= 1; id-- {
+		worklog, exists := m.worklogs[id]
+		if exists && worklog.TaskID == taskID && inWorklogRange(worklog, period) {
+			worklogs = append(worklogs, *m.withDuration(worklog))
+		}
+	}
+	return worklogs, nil
+}
+
+func (m *mockWorklogRepository) Delete(id int) error {
+	delete(m.worklogs, id)
+	return nil
+}
+
+func (m *mockWorklogRepository) SumByUser(taskID int, period model.WorklogRange) ([]model.UserTime, error) {
+	var totals []model.UserTime
+	index := map[int]int{}
+	for id := 1; id < m.nextID; id++ {
+		worklog, exists := m.worklogs[id]
+		if !exists || worklog.TaskID != taskID || !inWorklogRange(worklog, period) {
+			continue
+		}
+		i, seen := index[worklog.UserID]
+		if !seen {
+			i = len(totals)
+			index[worklog.UserID] = i
+			totals = append(totals, model.UserTime{UserID: worklog.UserID})
+		}
+		totals[i].Seconds += m.withDuration(worklog).DurationSeconds
+	}
+	return totals, nil
+}
+
+func (m *mockWorklogRepository) SumByTask(userID int, period model.WorklogRange) ([]model.TaskTime, error) {
+	var totals []model.TaskTime
+	index := map[int]int{}
+	for id := 1; id < m.nextID; id++ {
+		worklog, exists := m.worklogs[id]
+		if !exists || worklog.UserID != userID || !inWorklogRange(worklog, period) {
+			continue
+		}
+		i, seen := index[worklog.TaskID]
+		if !seen {
+			i = len(totals)
+			index[worklog.TaskID] = i
+			totals = append(totals, model.TaskTime{TaskID: worklog.TaskID})
+		}
+		totals[i].Seconds += m.withDuration(worklog).DurationSeconds
+	}
+	return totals, nil
+}
+
+func (m *mockWorklogRepository) withDuration(worklog *model.Worklog) *model.Worklog {
+	copied := *worklog
+	end := time.Now()
+	if copied.EndedAt != nil {
+		end = *copied.EndedAt
+	}
+	copied.DurationSeconds = int(end.Sub(copied.StartedAt).Seconds())
+	return &copied
+}
+
+func inWorklogRange(worklog *model.Worklog, period model.WorklogRange) bool {
+	if period.From != nil && worklog.StartedAt.Before(*period.From) {
+		return false
+	}
+	if period.To != nil && !worklog.StartedAt.Before(*period.To) {
+		return false
+	}
+	return true
+}
+
+func TestWorklogs(t *testing.T) {
+	// Task milik user 1 yang di-assign ke user 2; user 3 tidak punya akses
+	newWorklogService := func(t *testing.T) (service.WorklogService, *model.Task, service.TaskService) {
+		taskService := newTaskFixture().service()
+
+		assigneeID := 2
+		task, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Task", AssigneeID: &assigneeID})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return service.NewWorklogService(newMockWorklogRepository(), taskService), task, taskService
+	}
+
+	t.Run("FollowsTaskAuthorization", func(t *testing.T) {
+		worklogService, task, _ := newWorklogService(t)
+
+		if _, err := worklogService.StartTimer(task.ID, 2, &model.TimerStartRequest{}, false); err != nil {
+			t.Errorf("Expected assignee to start timer, got %v", err)
+		}
+
+		_, err := worklogService.StartTimer(task.ID, 3, &model.TimerStartRequest{}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+
+		_, err = worklogService.GetTaskWorklogs(task.ID, 3, model.WorklogRange{}, false)
+		if err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+	})
+
+	t.Run("OneRunningTimerPerUser", func(t *testing.T) {
+		worklogService, task, taskService := newWorklogService(t)
+		other, err := taskService.CreateTask(1, &model.TaskCreateRequest{Title: "Other"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if _, err := worklogService.StartTimer(task.ID, 1, &model.TimerStartRequest{}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, err = worklogService.StartTimer(other.ID, 1, &model.TimerStartRequest{}, false)
+		if err == nil || err.Error() != model.ErrTimerRunning {
+			t.Errorf("Expected %q error, got %v", model.ErrTimerRunning, err)
+		}
+
+		// Timer user lain tidak saling mengganggu
+		if _, err := worklogService.StartTimer(task.ID, 2, &model.TimerStartRequest{}, false); err != nil {
+			t.Errorf("Expected assignee to start own timer, got %v", err)
+		}
+
+		_, err = worklogService.StopTimer(other.ID, 1, false)
+		if err == nil || err.Error() != model.ErrTimerNotRunning {
+			t.Errorf("Expected %q error, got %v", model.ErrTimerNotRunning, err)
+		}
+
+		stopped, err := worklogService.StopTimer(task.ID, 1, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if stopped.Running() {
+			t.Error("Expected timer to be stopped")
+		}
+		if _, err := worklogService.GetRunningTimer(1); err == nil || err.Error() != model.ErrTimerNotRunning {
+			t.Errorf("Expected %q error, got %v", model.ErrTimerNotRunning, err)
+		}
+		if _, err := worklogService.StartTimer(other.ID, 1, &model.TimerStartRequest{}, false); err != nil {
+			t.Errorf("Expected new timer after stop, got %v", err)
+		}
+	})
+
+	t.Run("StopTimerOnTrashedTask", func(t *testing.T) {
+		worklogService, task, taskService := newWorklogService(t)
+
+		if _, err := worklogService.StartTimer(task.ID, 2, &model.TimerStartRequest{}, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := taskService.DeleteTask(task.ID, 1, false, model.SubtaskDeleteReparent); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		stopped, err := worklogService.StopTimer(task.ID, 2, false)
+		if err != nil {
+			t.Fatalf("Expected timer on trashed task to be stopped, got %v", err)
+		}
+		if stopped.Running() {
+			t.Error("Expected timer to be stopped")
+		}
+
+		// Tanpa timer berjalan, task di trash tetap dilaporkan tidak ditemukan
+		_, err = worklogService.StopTimer(task.ID, 2, false)
+		if err == nil || err.Error() != model.ErrTaskNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrTaskNotFound, err)
+		}
+	})
+
+	t.Run("ManualEntryRange", func(t *testing.T) {
+		worklogService, task, _ := newWorklogService(t)
+
+		now := time.Now()
+		invalid := []model.WorklogCreateRequest{
+			{StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-2 * time.Hour)},
+			{StartedAt: now.Add(-time.Hour), EndedAt: now.Add(time.Hour)},
+		}
+		for _, req := range invalid {
+			_, err := worklogService.CreateWorklog(task.ID, 1, &req, false)
+			if err == nil || err.Error() != model.ErrInvalidWorklogRange {
+				t.Errorf("Expected %q error, got %v", model.ErrInvalidWorklogRange, err)
+			}
+		}
+
+		worklog, err := worklogService.CreateWorklog(task.ID, 1, &model.WorklogCreateRequest{
+			StartedAt: now.Add(-90 * time.Minute),
+			EndedAt:   now.Add(-30 * time.Minute),
+		}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if worklog.DurationSeconds != 3600 {
+			t.Errorf("Expected 3600 seconds, got %d", worklog.DurationSeconds)
+		}
+	})
+
+	t.Run("TotalsOverRange", func(t *testing.T) {
+		worklogService, task, _ := newWorklogService(t)
+
+		day := time.Now().Add(-72 * time.Hour).Truncate(time.Hour)
+		entries := []struct {
+			userID int
+			start  time.Time
+			length time.Duration
+		}{
+			{1, day, time.Hour},
+			{2, day.Add(2 * time.Hour), 30 * time.Minute},
+			{1, day.Add(24 * time.Hour), 2 * time.Hour},
+		}
+		for _, entry := range entries {
+			req := &model.WorklogCreateRequest{StartedAt: entry.start, EndedAt: entry.start.Add(entry.length)}
+			if _, err := worklogService.CreateWorklog(task.ID, entry.userID, req, false); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		to := day.Add(24 * time.Hour)
+		resp, err := worklogService.GetTaskWorklogs(task.ID, 1, model.WorklogRange{From: &day, To: &to}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(resp.Worklogs) != 2 || resp.TotalSeconds != 5400 || len(resp.Users) != 2 {
+			t.Errorf("Expected 2 worklogs totalling 5400s for 2 users, got %d, %d, %d", len(resp.Worklogs), resp.TotalSeconds, len(resp.Users))
+		}
+
+		summary, err := worklogService.GetUserSummary(1, 1, model.WorklogRange{}, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if summary.TotalSeconds != 10800 || len(summary.Tasks) != 1 {
+			t.Errorf("Expected 10800s on 1 task, got %d on %d", summary.TotalSeconds, len(summary.Tasks))
+		}
+
+		if _, err := worklogService.GetUserSummary(1, 2, model.WorklogRange{}, false); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+		if _, err := worklogService.GetUserSummary(2, 3, model.WorklogRange{}, true); err != nil {
+			t.Errorf("Expected admin to view summary, got %v", err)
+		}
+	})
+
+	t.Run("DeletePermissions", func(t *testing.T) {
+		worklogService, task, _ := newWorklogService(t)
+
+		now := time.Now()
+		req := &model.WorklogCreateRequest{StartedAt: now.Add(-time.Hour), EndedAt: now}
+		worklog, err := worklogService.CreateWorklog(task.ID, 1, req, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if err := worklogService.DeleteWorklog(task.ID, worklog.ID, 2, false); err == nil || err.Error() != model.ErrForbidden {
+			t.Errorf("Expected %q error, got %v", model.ErrForbidden, err)
+		}
+		if err := worklogService.DeleteWorklog(task.ID, worklog.ID, 1, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := worklogService.DeleteWorklog(task.ID, worklog.ID, 1, false); err == nil || err.Error() != model.ErrWorklogNotFound {
+			t.Errorf("Expected %q error, got %v", model.ErrWorklogNotFound, err)
+		}
+	})
+}